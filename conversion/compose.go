@@ -0,0 +1,168 @@
+package conversion
+
+import "errors"
+
+var ErrConflict = errors.New("conflicting conversions")
+
+// MergePolicy decides what Merge does when both schemes register the same
+// pair.
+type MergePolicy int
+
+const (
+	// MergeError makes Merge return ErrConflict.
+	MergeError MergePolicy = iota
+
+	// MergeKeep keeps the conversion of the receiver.
+	MergeKeep
+
+	// MergeOverride replaces the conversion with the one from other.
+	MergeOverride
+)
+
+// Clone returns a copy of the scheme, with its own registered pairs and the
+// same parent. The copy is not ready, so more conversions can be registered on
+// it before calling its Build method.
+func (s *Scheme) Clone() *Scheme {
+	result := NewScheme()
+	for pair, fn := range s.convPairs {
+		result.convPairs[pair] = fn
+		result.sources[pair] = s.sources[pair]
+		result.elemFuncs[pair] = s.elemFuncs[pair]
+	}
+	for typ, fn := range s.defaulters {
+		result.defaulters[typ] = fn
+	}
+	for typ, fn := range s.validators {
+		result.validators[typ] = fn
+	}
+	result.parent = s.parent
+	result.noPanic = s.noPanic
+	result.fallback = s.fallback
+	result.middlewares = append(result.middlewares, s.middlewares...)
+	return result
+}
+
+// Merge returns a new scheme which has the pairs, defaulters and validators of
+// both schemes, including the ones inherited from their parents, and the
+// middlewares of the receiver. Neither scheme is modified. Conflicting
+// defaulters and validators are resolved as the pairs, except that they are
+// never an error.
+func (s *Scheme) Merge(other *Scheme, policy MergePolicy) (*Scheme, error) {
+	result := NewScheme()
+	result.noPanic = s.noPanic
+	result.fallback = s.fallback
+	result.middlewares = append(result.middlewares, s.middlewares...)
+	for pair, fn := range s.allPairs() {
+		result.convPairs[pair] = fn
+		result.sources[pair] = s.source(pair)
+		result.elemFuncs[pair] = s.elemFunc(pair)
+	}
+	if policy == MergeOverride {
+		copyHooks(result, other)
+	}
+	copyHooks(result, s)
+	copyHooks(result, other)
+	for pair, fn := range other.allPairs() {
+		if result.convPairs[pair] == nil {
+			result.convPairs[pair] = fn
+			result.sources[pair] = other.source(pair)
+			result.elemFuncs[pair] = other.elemFunc(pair)
+			continue
+		}
+		switch policy {
+		case MergeError:
+			return nil, newPairError(ErrConflict, pair,
+				"conflicting conversions (%v) registered by %v and %v", pair, result.sources[pair], other.source(pair))
+		case MergeKeep:
+		case MergeOverride:
+			result.convPairs[pair] = fn
+			result.sources[pair] = other.source(pair)
+			result.elemFuncs[pair] = other.elemFunc(pair)
+		default:
+			panic("unexpected")
+		}
+	}
+	result.ready = true
+	return result, nil
+}
+
+// Extend builds a child scheme. The conversions registered by funcs take
+// precedence over the ones of the parent, and any pair not found in the child
+// is looked up in the parent. The child starts with the middlewares of the
+// parent.
+func (s *Scheme) Extend(funcs ...func(*Scheme)) *Scheme {
+	child := NewScheme()
+	child.parent = s
+	child.noPanic = s.noPanic
+	child.fallback = s.fallback
+	child.middlewares = append(child.middlewares, s.middlewares...)
+	child, err := build(child, funcs)
+	if err != nil {
+		panic(err)
+	}
+	return child
+}
+
+func (s *Scheme) lookup(pair TypePair) ConversionFunc {
+	for ; s != nil; s = s.parent {
+		if fn := s.convPairs[pair]; fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// copyHooks copies the defaulters and validators of src and its parents which
+// are not already in dst.
+func copyHooks(dst, src *Scheme) {
+	for ; src != nil; src = src.parent {
+		for typ, fn := range src.defaulters {
+			if dst.defaulters[typ] == nil {
+				dst.defaulters[typ] = fn
+			}
+		}
+		for typ, fn := range src.validators {
+			if dst.validators[typ] == nil {
+				dst.validators[typ] = fn
+			}
+		}
+	}
+}
+
+func (s *Scheme) source(pair TypePair) string {
+	for ; s != nil; s = s.parent {
+		if s.convPairs[pair] != nil {
+			return s.sources[pair]
+		}
+	}
+	return ""
+}
+
+func (s *Scheme) elemFunc(pair TypePair) ConversionFunc {
+	for ; s != nil; s = s.parent {
+		if s.convPairs[pair] != nil {
+			return s.elemFuncs[pair]
+		}
+	}
+	return nil
+}
+
+// allPairs returns the registered pairs, including the ones inherited from
+// the parents.
+func (s *Scheme) allPairs() map[TypePair]ConversionFunc {
+	if s.parent == nil {
+		return s.convPairs
+	}
+	result := s.parent.allPairs()
+	if len(s.convPairs) == 0 {
+		return result
+	}
+	pairs := make(map[TypePair]ConversionFunc, len(result)+len(s.convPairs))
+	for pair, fn := range result {
+		pairs[pair] = fn
+	}
+	for pair, fn := range s.convPairs {
+		pairs[pair] = fn
+	}
+	return pairs
+}
@@ -1,10 +1,38 @@
 package conversion
 
+// Build creates a scheme and calls funcs to register conversions on it. It
+// panics if the registrations are invalid, such as registering the same pair
+// twice.
 func Build(funcs ...func(*Scheme)) *Scheme {
-	s := NewScheme()
+	s, err := TryBuild(funcs...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// TryBuild is like Build but returns the error instead of panicking.
+func TryBuild(funcs ...func(*Scheme)) (*Scheme, error) {
+	return build(NewScheme(), funcs)
+}
+
+// Build calls funcs to register more conversions on a scheme which is not
+// ready, such as a clone, then makes it ready. It panics as the Build func.
+func (s *Scheme) Build(funcs ...func(*Scheme)) *Scheme {
+	s, err := build(s, funcs)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func build(s *Scheme, funcs []func(*Scheme)) (*Scheme, error) {
 	for _, fn := range funcs {
 		fn(s)
 	}
+	if len(s.errs) != 0 {
+		return nil, s.errs[0]
+	}
 	s.ready = true
-	return s
+	return s, nil
 }
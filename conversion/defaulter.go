@@ -0,0 +1,93 @@
+package conversion
+
+import "reflect"
+
+// RegisterDefaulter registers the func for setting default values on objects
+// of the type of typ, which must be a pointer such as (*T)(nil). The scheme
+// calls it on the output objects of Convert, ConvertTo and ConvertChain, and
+// on each element of slice and map outputs.
+func (s *Scheme) RegisterDefaulter(typ interface{}, fn func(obj interface{})) {
+	if s.ready {
+		panic("register too late!")
+	}
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Ptr {
+		panic("must be pointer")
+	}
+	if s.defaulters[t.Elem()] != nil {
+		s.errs = append(s.errs, newError(ErrDuplicated, typ, nil, "duplicated defaulters for %v", t))
+		return
+	}
+	s.defaulters[t.Elem()] = fn
+}
+
+// Default calls the registered defaulter on obj, which can be a *T, []*T or
+// *[]*T. Nil objects are ignored.
+func (s *Scheme) Default(obj interface{}) {
+	eachObject(obj, func(_ int, _ interface{}, v reflect.Value) bool {
+		if fn := s.getDefaulter(v.Type().Elem()); fn != nil {
+			fn(v.Interface())
+		}
+		return true
+	})
+}
+
+func (s *Scheme) getDefaulter(typ reflect.Type) func(obj interface{}) {
+	for ; s != nil; s = s.parent {
+		if fn := s.defaulters[typ]; fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+func (s *Scheme) hasDefaulters() bool {
+	for ; s != nil; s = s.parent {
+		if len(s.defaulters) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// eachObject calls fn with each non-nil pointer in obj, which can be a *T,
+// []*T, []T, map[K]*T or a pointer to one of them. The index is -1 if obj is
+// not a slice, and the key is nil if obj is not a map. It stops when fn
+// returns false.
+func eachObject(obj interface{}, fn func(index int, key interface{}, v reflect.Value) bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr && !v.IsNil() &&
+		(v.Elem().Kind() == reflect.Slice || v.Elem().Kind() == reflect.Map) {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			fn(-1, nil, v)
+		}
+	case reflect.Slice:
+		for i, n := 0, v.Len(); i < n; i++ {
+			elem := v.Index(i)
+			if elem.Kind() != reflect.Ptr {
+				elem = elem.Addr()
+			}
+			if elem.IsNil() {
+				continue
+			}
+			if !fn(i, nil, elem) {
+				return
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := iter.Value()
+			if elem.Kind() != reflect.Ptr || elem.IsNil() {
+				continue
+			}
+			if !fn(-1, iter.Key().Interface(), elem) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrNoConversion    = errors.New("no conversion")
+	ErrAmbiguous       = errors.New("ambiguous conversions")
+	ErrInvalidTypePair = errors.New("invalid conversion type pair")
+	ErrNotReady        = errors.New("not ready")
+	ErrDuplicated      = errors.New("duplicated conversions")
+)
+
+// Error is returned (or panicked, unless the scheme is built with NoPanic)
+// when the scheme can not convert between the given types. It wraps one of
+// the sentinel errors, so it can be checked with errors.Is.
+type Error struct {
+	Err error
+	Arg reflect.Type
+	Out reflect.Type
+
+	msg string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(err error, arg, out interface{}, format string, args ...interface{}) *Error {
+	return &Error{
+		Err: err,
+		Arg: reflect.TypeOf(arg),
+		Out: reflect.TypeOf(out),
+		msg: fmt.Sprintf(format, args...),
+	}
+}
+
+func newPairError(err error, pair TypePair, format string, args ...interface{}) *Error {
+	argType, outType := pair.types()
+	return &Error{
+		Err: err,
+		Arg: argType,
+		Out: outType,
+		msg: fmt.Sprintf(format, args...),
+	}
+}
+
+func errNoConversion(arg, out interface{}) *Error {
+	if arg == nil && out == nil {
+		return newError(ErrNoConversion, nil, nil, "no conversion")
+	}
+	return newError(ErrNoConversion, arg, out, "no conversion between (%T -> %T)", arg, out)
+}
+
+func errInvalidTypePair(err error, arg, out interface{}) *Error {
+	return newError(ErrInvalidTypePair, arg, out, "invalid conversion type pair: %v (%T and %T)", err, arg, out)
+}
+
+var typeInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// types returns the types of the values which are passed to the conversion.
+// Map pairs are reported with interface{} keys, since they are registered for
+// any key type.
+func (p TypePair) types() (arg, out reflect.Type) {
+	switch {
+	case p.Slice && p.Value:
+		return reflect.SliceOf(p.Arg), reflect.PtrTo(reflect.SliceOf(p.Out))
+	case p.Slice:
+		return reflect.SliceOf(reflect.PtrTo(p.Arg)), reflect.PtrTo(reflect.SliceOf(reflect.PtrTo(p.Out)))
+	case p.Map:
+		return reflect.MapOf(typeInterface, reflect.PtrTo(p.Arg)),
+			reflect.PtrTo(reflect.MapOf(typeInterface, reflect.PtrTo(p.Out)))
+	default:
+		return reflect.PtrTo(p.Arg), reflect.PtrTo(p.Out)
+	}
+}
+
+// elem returns the pair for converting the elements of a slice or map pair.
+func (p TypePair) elem() TypePair {
+	return TypePair{Arg: p.Arg, Out: p.Out}
+}
+
+func (p TypePair) sameShape(other TypePair) bool {
+	return p.Slice == other.Slice && p.Map == other.Map && p.Value == other.Value
+}
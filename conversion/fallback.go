@@ -0,0 +1,153 @@
+package conversion
+
+import "reflect"
+
+// ReflectFallback configures the scheme to convert between struct types which
+// have no registered conversion by copying their fields at runtime. It uses
+// the same rules as the generator:
+//
+//   - Fields are matched by name. Unmatched fields are left unchanged.
+//   - Fields with identical types are assigned.
+//   - Basic types with the same kind, or both numeric, are converted.
+//   - Nested struct types, pointers and slices of pointers to them are
+//     converted using the registered conversions if available, or the
+//     fallback otherwise.
+//
+// The fields are only inspected the first time each pair is converted. The
+// fallback is slower than generated conversions and is meant for tooling.
+func ReflectFallback(s *Scheme) {
+	s.fallback = true
+}
+
+type fieldStep struct {
+	arg     int
+	out     int
+	convert func(arg, out reflect.Value) error
+}
+
+func (s *Scheme) buildFallback(pair TypePair) ConversionFunc {
+	if pair.Slice || pair.Map {
+		elemPair := pair.elem()
+		if s.getRoute(elemPair) == nil {
+			return nil
+		}
+		return convertElements(pair, func(arg, out interface{}) error {
+			return s.getRoute(elemPair).fn(arg, out)
+		})
+	}
+	if pair.Arg.Kind() != reflect.Struct || pair.Out.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var steps []fieldStep
+	for i, n := 0, pair.Out.NumField(); i < n; i++ {
+		outField := pair.Out.Field(i)
+		if outField.PkgPath != "" {
+			continue
+		}
+		argIdx := matchStructField(outField.Name, pair.Arg)
+		if argIdx < 0 {
+			continue
+		}
+		convert := s.fallbackField(pair.Arg.Field(argIdx).Type, outField.Type)
+		if convert == nil {
+			continue
+		}
+		steps = append(steps, fieldStep{arg: argIdx, out: i, convert: convert})
+	}
+	return func(arg, out interface{}) error {
+		argValue := reflect.ValueOf(arg).Elem()
+		outValue := reflect.ValueOf(out).Elem()
+		if !argValue.IsValid() {
+			return nil
+		}
+		for _, step := range steps {
+			if err := step.convert(argValue.Field(step.arg), outValue.Field(step.out)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func matchStructField(name string, st reflect.Type) int {
+	for i, n := 0, st.NumField(); i < n; i++ {
+		field := st.Field(i)
+		if field.PkgPath == "" && field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Scheme) fallbackField(argType, outType reflect.Type) func(arg, out reflect.Value) error {
+	if argType == outType {
+		return func(arg, out reflect.Value) error {
+			out.Set(arg)
+			return nil
+		}
+	}
+	if isBasicKind(argType.Kind()) && isBasicKind(outType.Kind()) {
+		if argType.Kind() == outType.Kind() ||
+			isNumericKind(argType.Kind()) && isNumericKind(outType.Kind()) {
+			return func(arg, out reflect.Value) error {
+				out.Set(arg.Convert(outType))
+				return nil
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case argType.Kind() == reflect.Struct && outType.Kind() == reflect.Struct:
+		pair := TypePair{Arg: argType, Out: outType}
+		return func(arg, out reflect.Value) error {
+			return s.convertNested(pair, arg.Addr().Interface(), out.Addr().Interface())
+		}
+
+	case argType.Kind() == reflect.Ptr && outType.Kind() == reflect.Ptr &&
+		argType.Elem().Kind() == reflect.Struct && outType.Elem().Kind() == reflect.Struct:
+		pair := TypePair{Arg: argType.Elem(), Out: outType.Elem()}
+		return func(arg, out reflect.Value) error {
+			if arg.IsNil() {
+				out.Set(reflect.Zero(outType))
+				return nil
+			}
+			elem := reflect.New(pair.Out)
+			if err := s.convertNested(pair, arg.Interface(), elem.Interface()); err != nil {
+				return err
+			}
+			out.Set(elem)
+			return nil
+		}
+
+	case argType.Kind() == reflect.Slice && outType.Kind() == reflect.Slice &&
+		argType.Elem().Kind() == reflect.Ptr && outType.Elem().Kind() == reflect.Ptr &&
+		argType.Elem().Elem().Kind() == reflect.Struct && outType.Elem().Elem().Kind() == reflect.Struct:
+		pair := TypePair{Slice: true, Arg: argType.Elem().Elem(), Out: outType.Elem().Elem()}
+		return func(arg, out reflect.Value) error {
+			return s.convertNested(pair, arg.Interface(), out.Addr().Interface())
+		}
+	}
+	return nil
+}
+
+// convertNested looks up the conversion lazily, so that the fallback of
+// recursive types can be built.
+func (s *Scheme) convertNested(pair TypePair, arg, out interface{}) error {
+	r := s.getRoute(pair)
+	if r == nil {
+		return newPairError(ErrNoConversion, pair, "no conversion between (%v)", pair)
+	}
+	return r.fn(arg, out)
+}
+
+func isBasicKind(kind reflect.Kind) bool {
+	return kind >= reflect.Bool && kind <= reflect.Complex128 || kind == reflect.String
+}
+
+// isNumericKind reports whether the kind is an integer or a float. Converting
+// between a complex and another numeric kind panics, so they are excluded.
+func isNumericKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
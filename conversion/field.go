@@ -0,0 +1,203 @@
+package conversion
+
+import "fmt"
+
+// Identity returns v, as the key func of maps with the same key type.
+func Identity[T any](v T) T {
+	return v
+}
+
+// CallInto calls fn with out, and copies the result into out if it differs.
+func CallInto[A, B any](fn func(arg *A, out *B) (*B, error), arg *A, out *B) error {
+	result, err := fn(arg, out)
+	if err == nil && result != nil && result != out {
+		*out = *result
+	}
+	return err
+}
+
+// ConvertMap converts a map with pointer elements by calling fn on each element.
+func ConvertMap[K1, K2 comparable, A, B any](
+	args map[K1]*A, key func(K1) K2, fn func(arg *A, out *B) (*B, error),
+) (map[K2]*B, error) {
+	if args == nil {
+		return nil, nil
+	}
+	outs := make(map[K2]*B, len(args))
+	for k, arg := range args {
+		if arg == nil {
+			outs[key(k)] = nil
+			continue
+		}
+		out, err := fn(arg, nil)
+		if err != nil {
+			return nil, err
+		}
+		outs[key(k)] = out
+	}
+	return outs, nil
+}
+
+// ConvertMapValue converts a map with value elements by calling fn on each element.
+func ConvertMapValue[K1, K2 comparable, A, B any](
+	args map[K1]A, key func(K1) K2, fn func(arg *A, out *B) (*B, error),
+) (map[K2]B, error) {
+	if args == nil {
+		return nil, nil
+	}
+	outs := make(map[K2]B, len(args))
+	for k, arg := range args {
+		arg := arg
+		var out B
+		if err := CallInto(fn, &arg, &out); err != nil {
+			return nil, err
+		}
+		outs[key(k)] = out
+	}
+	return outs, nil
+}
+
+// ConvertMapKeys converts the keys of a map by calling key on each key.
+func ConvertMapKeys[K1, K2 comparable, V any](args map[K1]V, key func(K1) K2) map[K2]V {
+	if args == nil {
+		return nil
+	}
+	outs := make(map[K2]V, len(args))
+	for k, v := range args {
+		outs[key(k)] = v
+	}
+	return outs
+}
+
+// ConvertSliceValue converts a slice with value elements by calling fn on each element.
+func ConvertSliceValue[A, B any](args []A, fn func(arg *A, out *B) (*B, error)) ([]B, error) {
+	if args == nil {
+		return nil, nil
+	}
+	outs := make([]B, len(args))
+	for i := range args {
+		if err := CallInto(fn, &args[i], &outs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+// ConvertValue converts a value by calling fn on it.
+func ConvertValue[A, B any](arg A, fn func(arg *A, out *B) (*B, error)) (out B, err error) {
+	err = CallInto(fn, &arg, &out)
+	return out, err
+}
+
+// ConvertPointerToValue converts a pointer to a value, or to the zero value if it is nil.
+func ConvertPointerToValue[A, B any](arg *A, fn func(arg *A, out *B) (*B, error)) (out B, err error) {
+	if arg == nil {
+		return out, nil
+	}
+	err = CallInto(fn, arg, &out)
+	return out, err
+}
+
+// ConvertValueToPointer converts a value to a new pointer by calling fn on it.
+func ConvertValueToPointer[A, B any](arg A, fn func(arg *A, out *B) (*B, error)) (*B, error) {
+	return fn(&arg, new(B))
+}
+
+// Pointer returns a pointer to v.
+func Pointer[T any](v T) *T {
+	return &v
+}
+
+// PointerValue returns the value of the pointer, or the zero value if v is nil.
+func PointerValue[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+// PointerValueOr returns the value of the pointer, or def if v is nil.
+func PointerValueOr[T any](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// RequirePointerValue returns the value of the pointer, or an error if v is nil.
+func RequirePointerValue[T any](v *T, name string) (T, error) {
+	if v == nil {
+		var zero T
+		return zero, fmt.Errorf("%v is required", name)
+	}
+	return *v, nil
+}
+
+// ConvertPointer converts a pointer by calling fn on its value.
+func ConvertPointer[A, B any](v *A, fn func(A) B) *B {
+	if v == nil {
+		return nil
+	}
+	out := fn(*v)
+	return &out
+}
+
+// ConvertPointerErr converts a pointer by calling fn on its value, which can fail.
+func ConvertPointerErr[A, B any](v *A, fn func(A) (B, error)) (*B, error) {
+	if v == nil {
+		return nil, nil
+	}
+	out, err := fn(*v)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConvertPointerValueOr converts the value of the pointer, or returns def if v is nil.
+func ConvertPointerValueOr[A, B any](v *A, def B, fn func(A) (B, error)) (B, error) {
+	if v == nil {
+		return def, nil
+	}
+	return fn(*v)
+}
+
+// ConvertRequiredPointer converts the value of the pointer, or returns an error if v is nil.
+func ConvertRequiredPointer[A, B any](v *A, name string, fn func(A) (B, error)) (B, error) {
+	if v == nil {
+		var zero B
+		return zero, fmt.Errorf("%v is required", name)
+	}
+	return fn(*v)
+}
+
+// NullValue returns v if it is valid, or the zero value.
+func NullValue[T any](v T, valid bool) T {
+	if !valid {
+		var zero T
+		return zero
+	}
+	return v
+}
+
+// NullPointer returns a pointer to v if it is valid, or nil.
+func NullPointer[T any](v T, valid bool) *T {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// ConvertNull converts v by calling fn on it if it is valid, and wraps it with null.
+func ConvertNull[A, B, N any](v A, valid bool, fn func(A) (B, error), null func(B, bool) N) (N, error) {
+	var out B
+	if valid {
+		var err error
+		if out, err = fn(v); err != nil {
+			var zero N
+			return zero, err
+		}
+	}
+	return null(out, valid), nil
+}
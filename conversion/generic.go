@@ -0,0 +1,91 @@
+package conversion
+
+import "reflect"
+
+// Register registers the conversion from *A to *B. Unlike Scheme.Register,
+// the types of the conversion func are checked by the compiler.
+func Register[A, B any](s *Scheme, fn func(arg *A, out *B) error) {
+	s.register((*A)(nil), (*B)(nil), func(arg, out interface{}) error {
+		return fn(arg.(*A), out.(*B))
+	}, false, 2)
+}
+
+// RegisterSlice registers the conversion from []*A to *[]*B. The output is
+// only assigned if fn succeeds.
+func RegisterSlice[A, B any](s *Scheme, fn func(args []*A) ([]*B, error)) {
+	s.register(([]*A)(nil), (*[]*B)(nil), func(arg, out interface{}) error {
+		outs, err := fn(arg.([]*A))
+		if err != nil {
+			return err
+		}
+		*out.(*[]*B) = outs
+		return nil
+	}, false, 2)
+}
+
+// RegisterValueSlice registers the conversion from []A to *[]B, which calls fn
+// on each element.
+func RegisterValueSlice[A, B any](s *Scheme, fn func(arg *A, out *B) error) {
+	pair := TypePair{Slice: true, Value: true, Arg: typeOf[A](), Out: typeOf[B]()}
+	elemFn := func(arg, out interface{}) error {
+		return fn(arg.(*A), out.(*B))
+	}
+	s.registerPair(pair, convertElements(pair, elemFn), elemFn, false, 2)
+}
+
+// RegisterMap registers the conversion from map[K]*A to *map[K]*B for any key
+// type K, which calls fn on each non-nil element.
+func RegisterMap[A, B any](s *Scheme, fn func(arg *A, out *B) error) {
+	pair := TypePair{Map: true, Arg: typeOf[A](), Out: typeOf[B]()}
+	elemFn := func(arg, out interface{}) error {
+		return fn(arg.(*A), out.(*B))
+	}
+	s.registerPair(pair, convertElements(pair, elemFn), elemFn, false, 2)
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// To converts arg to a new *B. It returns nil if arg is nil.
+//
+//	user, err := conversion.To[db.User](scheme, apiUser)
+func To[B, A any](s *Scheme, arg *A) (*B, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	out := new(B)
+	if err := s.Convert(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Into converts arg into the existing out.
+func Into[A, B any](s *Scheme, arg *A, out *B) error {
+	return s.Convert(arg, out)
+}
+
+// ToSlice converts args to a new []*B. It returns nil if args is nil.
+func ToSlice[B, A any](s *Scheme, args []*A) ([]*B, error) {
+	if args == nil {
+		return nil, nil
+	}
+	var outs []*B
+	if err := s.Convert(args, &outs); err != nil {
+		return nil, err
+	}
+	return outs, nil
+}
+
+// ToMap converts args to a new map[K]*B. It returns nil if args is nil.
+func ToMap[B, A any, K comparable](s *Scheme, args map[K]*A) (map[K]*B, error) {
+	if args == nil {
+		return nil, nil
+	}
+	var outs map[K]*B
+	if err := s.Convert(args, &outs); err != nil {
+		return nil, err
+	}
+	return outs, nil
+}
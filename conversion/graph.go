@@ -0,0 +1,157 @@
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+func (p TypePair) String() string {
+	switch {
+	case p.Slice && p.Value:
+		return fmt.Sprintf("[]%v -> *[]%v", p.Arg, p.Out)
+	case p.Slice:
+		return fmt.Sprintf("[]*%v -> *[]*%v", p.Arg, p.Out)
+	case p.Map:
+		return fmt.Sprintf("map[K]*%v -> *map[K]*%v", p.Arg, p.Out)
+	default:
+		return fmt.Sprintf("*%v -> *%v", p.Arg, p.Out)
+	}
+}
+
+// Pairs returns the registered type pairs, including the ones inherited from
+// the parent scheme, sorted by their arg and out types.
+func (s *Scheme) Pairs() []TypePair {
+	convPairs := s.allPairs()
+	pairs := make([]TypePair, 0, len(convPairs))
+	for pair := range convPairs {
+		pairs = append(pairs, pair)
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// Has reports whether there is a registered conversion between the given
+// types. It does not take multi-hop routes into account, use Route for that.
+func (s *Scheme) Has(arg, out interface{}) bool {
+	pair, err := getTypePair(arg, out)
+	if err != nil {
+		return false
+	}
+	return s.lookup(pair) != nil
+}
+
+// WriteDOT writes the conversion graph in Graphviz DOT format. Each type is a
+// node and each registered pair is an edge. Slice pairs are drawn as dashed
+// edges, value slice pairs are labeled with "[]" and map pairs are drawn as
+// dotted edges.
+func (s *Scheme) WriteDOT(w io.Writer) error {
+	pairs := s.Pairs()
+	nodes := make(map[string]bool)
+	var names []string
+	for _, pair := range pairs {
+		for _, typ := range []reflect.Type{pair.Arg, pair.Out} {
+			name := typeName(typ)
+			if !nodes[name] {
+				nodes[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	p := &errWriter{w: w}
+	p.printf("digraph conversion {\n")
+	for _, name := range names {
+		p.printf("\t%v;\n", strconv.Quote(name))
+	}
+	for _, pair := range pairs {
+		p.printf("\t%v -> %v", strconv.Quote(typeName(pair.Arg)), strconv.Quote(typeName(pair.Out)))
+		switch {
+		case pair.Slice && pair.Value:
+			p.printf(` [style=dashed, label="[]"]`)
+		case pair.Slice:
+			p.printf(" [style=dashed]")
+		case pair.Map:
+			p.printf(" [style=dotted]")
+		}
+		p.printf(";\n")
+	}
+	p.printf("}\n")
+	return p.err
+}
+
+type jsonPair struct {
+	Arg   string `json:"arg"`
+	Out   string `json:"out"`
+	Slice bool   `json:"slice"`
+	Map   bool   `json:"map,omitempty"`
+	Value bool   `json:"value,omitempty"`
+}
+
+// WriteJSON writes the registered pairs as a JSON array.
+func (s *Scheme) WriteJSON(w io.Writer) error {
+	pairs := s.Pairs()
+	result := make([]jsonPair, len(pairs))
+	for i, pair := range pairs {
+		result[i] = jsonPair{
+			Arg:   typeName(pair.Arg),
+			Out:   typeName(pair.Out),
+			Slice: pair.Slice,
+			Map:   pair.Map,
+			Value: pair.Value,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+func sortPairs(pairs []TypePair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.Arg != b.Arg {
+			return typeName(a.Arg) < typeName(b.Arg)
+		}
+		if a.Out != b.Out {
+			return typeName(a.Out) < typeName(b.Out)
+		}
+		return shapeOrder(a) < shapeOrder(b)
+	})
+}
+
+func shapeOrder(p TypePair) int {
+	switch {
+	case p.Slice && p.Value:
+		return 2
+	case p.Slice:
+		return 1
+	case p.Map:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// typeName returns the type name qualified with its full package path.
+func typeName(typ reflect.Type) string {
+	if typ.Name() == "" || typ.PkgPath() == "" {
+		return typ.String()
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *errWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
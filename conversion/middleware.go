@@ -0,0 +1,85 @@
+package conversion
+
+import "reflect"
+
+// Middleware wraps the conversion func of the given pair, for adding logging,
+// tracing, metrics or panic recovery around conversions.
+type Middleware func(pair TypePair, next ConversionFunc) ConversionFunc
+
+// Use adds middlewares to the scheme. They are applied in order, the first
+// one is the outermost. Middlewares apply to Convert, ConvertTo and
+// ConvertChain, and to each step of a multi-hop route.
+//
+// For a slice or map pair registered with RegisterValueSlice or RegisterMap,
+// the middlewares are also called for each element, with the element pair.
+// The funcs registered for a whole slice, such as with RegisterSlice, are
+// only wrapped with the slice pair.
+func (s *Scheme) Use(mws ...Middleware) {
+	if s.ready {
+		panic("use too late!")
+	}
+	s.middlewares = append(s.middlewares, mws...)
+}
+
+func (s *Scheme) intercept(pair TypePair, fn ConversionFunc) ConversionFunc {
+	if len(s.middlewares) == 0 {
+		return fn
+	}
+	if elemFn := s.elemFunc(pair); elemFn != nil {
+		fn = convertElements(pair, s.intercept(pair.elem(), elemFn))
+	}
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		fn = s.middlewares[i](pair, fn)
+	}
+	return fn
+}
+
+// convertElements converts the slice or map pair by calling fn on each
+// element. Nil elements stay nil. The output is only set when all elements
+// are converted successfully.
+func convertElements(pair TypePair, fn ConversionFunc) ConversionFunc {
+	return func(arg, out interface{}) error {
+		args := reflect.ValueOf(arg)
+		outs := reflect.ValueOf(out).Elem()
+		if args.IsNil() {
+			outs.Set(reflect.Zero(outs.Type()))
+			return nil
+		}
+		if pair.Map {
+			result := reflect.MakeMapWithSize(outs.Type(), args.Len())
+			iter := args.MapRange()
+			for iter.Next() {
+				elem := reflect.Zero(outs.Type().Elem())
+				if !iter.Value().IsNil() {
+					elem = reflect.New(pair.Out)
+					if err := fn(iter.Value().Interface(), elem.Interface()); err != nil {
+						return err
+					}
+				}
+				result.SetMapIndex(iter.Key(), elem)
+			}
+			outs.Set(result)
+			return nil
+		}
+
+		result := reflect.MakeSlice(outs.Type(), args.Len(), args.Len())
+		for i, n := 0, args.Len(); i < n; i++ {
+			if pair.Value {
+				if err := fn(args.Index(i).Addr().Interface(), result.Index(i).Addr().Interface()); err != nil {
+					return err
+				}
+				continue
+			}
+			if args.Index(i).IsNil() {
+				continue
+			}
+			elem := reflect.New(pair.Out)
+			if err := fn(args.Index(i).Interface(), elem.Interface()); err != nil {
+				return err
+			}
+			result.Index(i).Set(elem)
+		}
+		outs.Set(result)
+		return nil
+	}
+}
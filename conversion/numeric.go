@@ -0,0 +1,49 @@
+package conversion
+
+import (
+	"fmt"
+	"math"
+)
+
+// Integer is the constraint of the integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the constraint of the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// CheckedInt converts an integer to another integer type, or returns an error on overflow.
+func CheckedInt[B, A Integer](v A, name string) (B, error) {
+	out := B(v)
+	if A(out) != v || (v < 0) != (out < 0) {
+		return 0, errOutOfRange(name, v, out)
+	}
+	return out, nil
+}
+
+// CheckedFloatToInt converts a float to an integer type, or returns an error if it is out of range.
+func CheckedFloatToInt[B Integer, A Float](v A, name string) (B, error) {
+	t := math.Trunc(float64(v))
+	out := B(t)
+	if float64(out) != t || (t < 0) != (out < 0) {
+		return 0, errOutOfRange(name, v, out)
+	}
+	return out, nil
+}
+
+// CheckedFloat converts a float to another float type, or returns an error on overflow.
+func CheckedFloat[B, A Float](v A, name string) (B, error) {
+	out := B(v)
+	if math.IsInf(float64(out), 0) && !math.IsInf(float64(v), 0) {
+		return 0, errOutOfRange(name, v, out)
+	}
+	return out, nil
+}
+
+func errOutOfRange(name string, v, out interface{}) error {
+	return fmt.Errorf("%v: value %v is out of range of %T", name, v, out)
+}
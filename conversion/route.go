@@ -0,0 +1,151 @@
+package conversion
+
+import (
+	"reflect"
+	"sort"
+)
+
+type route struct {
+	pairs []TypePair
+	fn    ConversionFunc
+}
+
+// Route returns the conversion steps which Convert uses to convert between
+// the given types. It contains a single pair when there is a direct
+// conversion, or the shortest path of registered pairs otherwise.
+func (s *Scheme) Route(arg, out interface{}) ([]TypePair, error) {
+	if err := s.ensureReady(); err != nil {
+		return nil, err
+	}
+	pair, err := getTypePair(arg, out)
+	if err != nil {
+		return nil, errInvalidTypePair(err, arg, out)
+	}
+	r := s.getRoute(pair)
+	if r == nil {
+		return nil, errNoConversion(arg, out)
+	}
+	pairs := make([]TypePair, len(r.pairs))
+	copy(pairs, r.pairs)
+	return pairs, nil
+}
+
+// getRoute finds the route between the pair, caching the result. The scheme
+// must be ready, so the registered pairs do not change anymore.
+func (s *Scheme) getRoute(pair TypePair) *route {
+	s.mu.RLock()
+	r, ok := s.routes[pair]
+	s.mu.RUnlock()
+	if ok {
+		return r
+	}
+
+	r = s.findRoute(pair)
+	s.mu.Lock()
+	s.routes[pair] = r
+	s.mu.Unlock()
+	return r
+}
+
+func (s *Scheme) findRoute(pair TypePair) *route {
+	if fn := s.lookup(pair); fn != nil {
+		return &route{pairs: []TypePair{pair}, fn: s.intercept(pair, fn)}
+	}
+
+	// breadth-first search for the shortest path
+	convPairs := s.allPairs()
+	prevs := make(map[reflect.Type]TypePair)
+	visited := map[reflect.Type]bool{pair.Arg: true}
+	queue := []reflect.Type{pair.Arg}
+	for len(queue) > 0 {
+		typ := queue[0]
+		queue = queue[1:]
+		for _, next := range nextPairs(convPairs, typ, pair) {
+			if visited[next.Out] {
+				continue
+			}
+			visited[next.Out] = true
+			prevs[next.Out] = next
+			if next.Out == pair.Out {
+				return s.buildRoute(convPairs, pair, prevs)
+			}
+			queue = append(queue, next.Out)
+		}
+	}
+
+	if s.fallback {
+		if fn := s.buildFallback(pair); fn != nil {
+			return &route{pairs: []TypePair{pair}, fn: s.intercept(pair, fn)}
+		}
+	}
+	return nil
+}
+
+// nextPairs returns the registered pairs which convert from the given type
+// with the same shape as target, sorted by the output type for a
+// deterministic result.
+func nextPairs(convPairs map[TypePair]ConversionFunc, arg reflect.Type, target TypePair) []TypePair {
+	var pairs []TypePair
+	for pair := range convPairs {
+		if pair.sameShape(target) && pair.Arg == arg {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Out.String() < pairs[j].Out.String()
+	})
+	return pairs
+}
+
+func (s *Scheme) buildRoute(convPairs map[TypePair]ConversionFunc, pair TypePair, prevs map[reflect.Type]TypePair) *route {
+	var pairs []TypePair
+	for typ := pair.Out; typ != pair.Arg; {
+		prev := prevs[typ]
+		pairs = append(pairs, prev)
+		typ = prev.Arg
+	}
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	fns := make([]ConversionFunc, len(pairs))
+	for i, p := range pairs {
+		fns[i] = s.intercept(p, convPairs[p])
+	}
+	return &route{pairs: pairs, fn: chainConversions(pairs, fns)}
+}
+
+// chainConversions calls the conversion funcs in order, allocating the
+// intermediate objects between them.
+func chainConversions(pairs []TypePair, fns []ConversionFunc) ConversionFunc {
+	return func(arg, out interface{}) error {
+		last := len(pairs) - 1
+		for i, pair := range pairs[:last] {
+			next := newIntermediate(pair, arg)
+			if err := fns[i](arg, next); err != nil {
+				return err
+			}
+			if pair.Slice || pair.Map {
+				arg = reflect.ValueOf(next).Elem().Interface()
+			} else {
+				arg = next
+			}
+		}
+		return fns[last](arg, out)
+	}
+}
+
+// newIntermediate allocates the output of the pair. Map outputs use the same
+// key type as arg.
+func newIntermediate(pair TypePair, arg interface{}) interface{} {
+	switch {
+	case pair.Slice && pair.Value:
+		return reflect.New(reflect.SliceOf(pair.Out)).Interface()
+	case pair.Slice:
+		return reflect.New(reflect.SliceOf(reflect.PtrTo(pair.Out))).Interface()
+	case pair.Map:
+		keyType := reflect.TypeOf(arg).Key()
+		return reflect.New(reflect.MapOf(keyType, reflect.PtrTo(pair.Out))).Interface()
+	default:
+		return reflect.New(pair.Out).Interface()
+	}
+}
@@ -4,10 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"sync"
 )
 
+// TypePair is the key of a registered conversion. Arg and Out are the element
+// types, and the flags describe the shape of the converted values:
+//
+//	*Arg        -> *Out
+//	[]*Arg      -> *[]*Out         Slice
+//	[]Arg       -> *[]Out          Slice, Value
+//	map[K]*Arg  -> *map[K]*Out     Map (for any key type K)
 type TypePair struct {
 	Slice bool
+	Map   bool
+	Value bool
 	Arg   reflect.Type
 	Out   reflect.Type
 }
@@ -16,16 +27,56 @@ type ConversionFunc func(arg, out interface{}) error
 
 type Scheme struct {
 	convPairs map[TypePair]ConversionFunc
+	sources   map[TypePair]string
+	elemFuncs map[TypePair]ConversionFunc
+	parent    *Scheme
 	ready     bool
+	noPanic   bool
+	fallback  bool
+	errs      []error
+
+	middlewares []Middleware
+	defaulters  map[reflect.Type]func(obj interface{})
+	validators  map[reflect.Type]func(obj interface{}) error
+
+	mu     sync.RWMutex
+	routes map[TypePair]*route
 }
 
 func NewScheme() *Scheme {
 	return &Scheme{
 		convPairs: make(map[TypePair]ConversionFunc),
+		sources:   make(map[TypePair]string),
+		elemFuncs: make(map[TypePair]ConversionFunc),
+		routes:    make(map[TypePair]*route),
+
+		defaulters: make(map[reflect.Type]func(obj interface{})),
+		validators: make(map[reflect.Type]func(obj interface{}) error),
 	}
 }
 
+// NoPanic configures the scheme to return an *Error instead of panicking
+// when there is no conversion between the given types. It is meant to be
+// passed to Build together with the registration funcs.
+func NoPanic(s *Scheme) {
+	s.noPanic = true
+}
+
+// Register registers the conversion between the types of arg and out.
+// Registering the same pair twice is an error, which is reported by Build.
 func (s *Scheme) Register(arg, out interface{}, fn ConversionFunc) {
+	s.register(arg, out, fn, false, 2)
+}
+
+// Override registers the conversion between the types of arg and out,
+// replacing the existing one.
+func (s *Scheme) Override(arg, out interface{}, fn ConversionFunc) {
+	s.register(arg, out, fn, true, 2)
+}
+
+// register records the caller at the given skip level as the source of the
+// registration.
+func (s *Scheme) register(arg, out interface{}, fn ConversionFunc, override bool, skip int) {
 	if s.ready {
 		panic("register too late!")
 	}
@@ -33,36 +84,74 @@ func (s *Scheme) Register(arg, out interface{}, fn ConversionFunc) {
 	if err != nil {
 		panic(err)
 	}
+	s.registerPair(pair, fn, nil, override, skip+1)
+}
+
+// registerPair registers fn for the pair. The elemFn is the conversion of the
+// elements of a slice or map pair which is converted one by one, or nil.
+func (s *Scheme) registerPair(pair TypePair, fn, elemFn ConversionFunc, override bool, skip int) {
+	source := getCaller(skip + 1)
+	if s.convPairs[pair] != nil && !override {
+		s.errs = append(s.errs, newPairError(ErrDuplicated, pair,
+			"duplicated conversions (%v) registered by %v and %v", pair, s.sources[pair], source))
+		return
+	}
 	s.convPairs[pair] = fn
+	s.sources[pair] = source
+	s.elemFuncs[pair] = elemFn
 }
 
-func (s *Scheme) ensureReady() {
+func getCaller(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		return fmt.Sprintf("%v (%v:%v)", fn.Name(), file, line)
+	}
+	return fmt.Sprintf("%v:%v", file, line)
+}
+
+func (s *Scheme) ensureReady() error {
 	if !s.ready {
-		panic("not ready")
+		return newError(ErrNotReady, nil, nil, "not ready")
 	}
+	return nil
 }
 
-func invalidMessage(a0, b0 interface{}) string {
-	if a0 == nil && b0 == nil {
-		return fmt.Sprintf("no conversion")
+// fail panics with the error, or returns it if the scheme is configured with
+// NoPanic.
+func (s *Scheme) fail(err error) error {
+	if s.noPanic {
+		return err
 	}
-	return fmt.Sprintf("no conversion between (%T -> %T)", a0, b0)
+	panic(err)
 }
 
 func (s *Scheme) ConvertTo(args ...interface{}) error {
-	s.ensureReady()
-	c, a, b := s.validateConvertTo(args)
+	if err := s.ensureReady(); err != nil {
+		return s.fail(err)
+	}
+	c, a, b, err := s.validateConvertTo(args)
+	if err != nil {
+		return s.fail(err)
+	}
 	if c == 0 {
-		panic(invalidMessage(a, b))
+		return s.fail(errNoConversion(a, b))
 	}
 	return s.convertTo(args)
 }
 
 func (s *Scheme) ConvertChain(args ...interface{}) error {
-	s.ensureReady()
-	c, a, b := s.validateConvertTo(args)
+	if err := s.ensureReady(); err != nil {
+		return s.fail(err)
+	}
+	c, a, b, err := s.validateConvertChain(args)
+	if err != nil {
+		return s.fail(err)
+	}
 	if c == 0 {
-		panic(invalidMessage(a, b))
+		return s.fail(errNoConversion(a, b))
 	}
 	return s.convertChain(args)
 }
@@ -72,32 +161,48 @@ func (s *Scheme) ConvertChain(args ...interface{}) error {
 //
 
 func (s *Scheme) Convert(args ...interface{}) error {
-	s.ensureReady()
+	if err := s.ensureReady(); err != nil {
+		return s.fail(err)
+	}
 
+	// with only 2 params, fallback to the shortest route of registered
+	// conversions when there is no direct conversion between them
 	if len(args) == 2 {
 		arg, out := args[0], args[1]
-		fn := s.getConversion(arg, out)
-		if fn == nil {
-			panic(fmt.Sprintf("no conversion between (%T -> %T)", arg, out))
+		pair, err := getTypePair(arg, out)
+		if err != nil {
+			return s.fail(errInvalidTypePair(err, arg, out))
+		}
+		r := s.getRoute(pair)
+		if r == nil {
+			return s.fail(errNoConversion(arg, out))
+		}
+		if err := r.fn(arg, out); err != nil {
+			return err
 		}
-		return fn(arg, out)
+		return s.afterConvert(pair, out)
 	}
 
-	cc, a0, b0 := s.validateConvertChain(args)
-	ct, a1, b1 := s.validateConvertTo(args)
+	cc, a0, b0, err := s.validateConvertChain(args)
+	if err != nil {
+		return s.fail(err)
+	}
+	ct, a1, b1, err := s.validateConvertTo(args)
+	if err != nil {
+		return s.fail(err)
+	}
 	switch {
 	case cc == 0 && ct == 0:
-		if a0 == nil && b0 == nil {
-			panic(fmt.Sprintf("no conversion"))
-		}
 		if a0 == a1 && b0 == b1 {
-			panic(fmt.Sprintf("no conversion between (%T -> %T)", a0, b0))
+			return s.fail(errNoConversion(a0, b0))
 		}
-		panic(fmt.Sprintf("no conversion between (%T -> %T) and (%T -> %T)", a0, b0, a1, b1))
+		return s.fail(newError(ErrNoConversion, a0, b0,
+			"no conversion between (%T -> %T) and (%T -> %T)", a0, b0, a1, b1))
 
 	case cc > 1 && ct > 1:
-		panic(fmt.Sprintf("ambiguous conversions between (%T -> %T) and (%T -> %T)"+
-			" (note: use ConvertTo or ConvertChain instead)", a0, b0, a1, b1))
+		return s.fail(newError(ErrAmbiguous, a0, b0,
+			"ambiguous conversions between (%T -> %T) and (%T -> %T)"+
+				" (note: use ConvertTo or ConvertChain instead)", a0, b0, a1, b1))
 
 	case cc == 1 && ct == 1:
 		return s.convertChain(args)
@@ -110,6 +215,7 @@ func (s *Scheme) Convert(args ...interface{}) error {
 }
 
 func (s *Scheme) convertTo(args []interface{}) error {
+	var pair TypePair
 	last := getLastNonFunc(args)
 	for _, arg := range args {
 		if arg == last {
@@ -123,12 +229,14 @@ func (s *Scheme) convertTo(args []interface{}) error {
 				return err
 			}
 		default:
-			if err := s.getConversion(arg, last)(arg, last); err != nil {
+			conv, _ := s.getConversion(arg, last)
+			if err := conv(arg, last); err != nil {
 				return err
 			}
+			pair, _ = getTypePair(arg, last)
 		}
 	}
-	return nil
+	return s.afterConvert(pair, last)
 }
 
 func (s *Scheme) convertChain(args []interface{}) error {
@@ -143,7 +251,12 @@ func (s *Scheme) convertChain(args []interface{}) error {
 			}
 		default:
 			if prev != nil {
-				if err := s.getConversion(prev, arg)(prev, arg); err != nil {
+				conv, _ := s.getConversion(prev, arg)
+				if err := conv(prev, arg); err != nil {
+					return err
+				}
+				pair, _ := getTypePair(prev, arg)
+				if err := s.afterConvert(pair, arg); err != nil {
 					return err
 				}
 			}
@@ -153,6 +266,18 @@ func (s *Scheme) convertChain(args []interface{}) error {
 	return nil
 }
 
+// afterConvert applies the registered defaulters and validators on the output
+// object of the conversion.
+func (s *Scheme) afterConvert(pair TypePair, out interface{}) error {
+	if s.hasDefaulters() {
+		s.Default(out)
+	}
+	if s.hasValidators() {
+		return s.validate(pair, out)
+	}
+	return nil
+}
+
 func getLastNonFunc(args []interface{}) interface{} {
 	for i := len(args) - 1; i >= 0; i-- {
 		switch args[i].(type) {
@@ -164,25 +289,29 @@ func getLastNonFunc(args []interface{}) interface{} {
 	return nil
 }
 
-func (s *Scheme) validateConvertTo(args []interface{}) (count int, pairA, pairB interface{}) {
+func (s *Scheme) validateConvertTo(args []interface{}) (count int, pairA, pairB interface{}, err error) {
 	last := getLastNonFunc(args)
 	for _, arg := range args {
 		switch arg.(type) {
 		case func(), func() error:
 			continue
 		}
-		if s.getConversion(arg, last) == nil {
-			return 0, arg, last
+		fn, err := s.getConversion(arg, last)
+		if err != nil {
+			return 0, arg, last, err
+		}
+		if fn == nil {
+			return 0, arg, last, nil
 		}
 		if pairA == nil {
 			pairA, pairB = arg, last
 		}
 		count++
 	}
-	return count, pairA, pairB
+	return count, pairA, pairB, nil
 }
 
-func (s *Scheme) validateConvertChain(args []interface{}) (count int, pairA, pairB interface{}) {
+func (s *Scheme) validateConvertChain(args []interface{}) (count int, pairA, pairB interface{}, err error) {
 	var prev interface{}
 	for _, arg := range args {
 		switch arg.(type) {
@@ -193,8 +322,12 @@ func (s *Scheme) validateConvertChain(args []interface{}) (count int, pairA, pai
 			prev = arg
 			continue
 		}
-		if s.getConversion(prev, arg) == nil {
-			return 0, prev, arg
+		fn, err := s.getConversion(prev, arg)
+		if err != nil {
+			return 0, prev, arg, err
+		}
+		if fn == nil {
+			return 0, prev, arg, nil
 		}
 		if pairA == nil {
 			pairA, pairB = prev, arg
@@ -202,22 +335,28 @@ func (s *Scheme) validateConvertChain(args []interface{}) (count int, pairA, pai
 		prev = arg
 		count++
 	}
-	return count, pairA, pairB
+	return count, pairA, pairB, nil
 }
 
-func (s *Scheme) getConversion(arg, out interface{}) ConversionFunc {
+func (s *Scheme) getConversion(arg, out interface{}) (ConversionFunc, error) {
 	pair, err := getTypePair(arg, out)
 	if err != nil {
-		panic(fmt.Sprintf("invalid conversion type pair: %v (%T and %T)", err, arg, out))
+		return nil, errInvalidTypePair(err, arg, out)
 	}
-	fn := s.convPairs[pair]
-	return fn
+	r := s.getRoute(pair)
+	if r == nil || len(r.pairs) != 1 {
+		return nil, nil
+	}
+	return r.fn, nil
 }
 
 func getTypePair(arg, out interface{}) (TypePair, error) {
 	argType := reflect.TypeOf(arg)
 	outType := reflect.TypeOf(out)
 	switch {
+	case argType == nil || outType == nil:
+		return TypePair{}, errors.New("must not be nil")
+
 	case argType.Kind() == reflect.Slice && outType.Kind() == reflect.Slice:
 		return TypePair{}, errors.New("second param must be pointer to slice")
 
@@ -232,7 +371,36 @@ func getTypePair(arg, out interface{}) (TypePair, error) {
 			}
 			return pair, nil
 		}
-		return TypePair{}, errors.New("must be slice of pointer")
+		if argType.Elem().Kind() != reflect.Ptr &&
+			outType.Elem().Elem().Kind() != reflect.Ptr {
+			pair := TypePair{
+				Slice: true,
+				Value: true,
+				Arg:   argType.Elem(),
+				Out:   outType.Elem().Elem(),
+			}
+			return pair, nil
+		}
+		return TypePair{}, errors.New("must be slice of pointer or slice of value")
+
+	case argType.Kind() == reflect.Map && outType.Kind() == reflect.Map:
+		return TypePair{}, errors.New("second param must be pointer to map")
+
+	case argType.Kind() == reflect.Map &&
+		outType.Kind() == reflect.Ptr && outType.Elem().Kind() == reflect.Map:
+		if argType.Key() != outType.Elem().Key() {
+			return TypePair{}, errors.New("map keys must match")
+		}
+		if argType.Elem().Kind() == reflect.Ptr &&
+			outType.Elem().Elem().Kind() == reflect.Ptr {
+			pair := TypePair{
+				Map: true,
+				Arg: argType.Elem().Elem(),
+				Out: outType.Elem().Elem().Elem(),
+			}
+			return pair, nil
+		}
+		return TypePair{}, errors.New("must be map of pointer")
 
 	case argType.Kind() != reflect.Slice && outType.Kind() != reflect.Slice:
 		if argType.Kind() == reflect.Ptr &&
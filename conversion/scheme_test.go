@@ -1,7 +1,11 @@
 package conversion
 
 import (
+	"encoding/json"
+	"errors"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -60,3 +64,667 @@ func TestScheme(t *testing.T) {
 		require.Equal(t, bs[1].Value, "20")
 	})
 }
+
+func TestSchemeErrors(t *testing.T) {
+	s := Build(NoPanic, func(s *Scheme) {
+		s.Register((*A)(nil), (*B)(nil), func(a, b interface{}) error {
+			return nil
+		})
+		s.Register((*B)(nil), (*B)(nil), func(a, b interface{}) error {
+			return nil
+		})
+	})
+
+	t.Run("no conversion", func(t *testing.T) {
+		err := s.Convert(&B{}, &A{})
+		require.True(t, errors.Is(err, ErrNoConversion))
+		var convErr *Error
+		require.True(t, errors.As(err, &convErr))
+		require.Equal(t, reflect.TypeOf(&B{}), convErr.Arg)
+		require.Equal(t, reflect.TypeOf(&A{}), convErr.Out)
+		require.EqualError(t, err, "no conversion between (*conversion.B -> *conversion.A)")
+	})
+	t.Run("invalid type pair", func(t *testing.T) {
+		err := s.Convert(A{}, &B{})
+		require.True(t, errors.Is(err, ErrInvalidTypePair))
+		require.EqualError(t, err, "invalid conversion type pair: must be pointer (conversion.A and *conversion.B)")
+	})
+	t.Run("ambiguous", func(t *testing.T) {
+		err := s.Convert(&A{}, &B{}, &B{})
+		require.True(t, errors.Is(err, ErrAmbiguous))
+	})
+	t.Run("not ready", func(t *testing.T) {
+		s := NewScheme()
+		s.noPanic = true
+		err := s.ConvertTo(&A{}, &B{})
+		require.True(t, errors.Is(err, ErrNotReady))
+	})
+	t.Run("panic by default", func(t *testing.T) {
+		s := Build()
+		require.Panics(t, func() { _ = s.Convert(&A{}, &B{}) })
+	})
+}
+
+type C struct {
+	Value int64
+}
+
+func TestSchemeRoute(t *testing.T) {
+	s := Build(NoPanic, func(s *Scheme) {
+		s.Register((*A)(nil), (*B)(nil), func(a, b interface{}) error {
+			b.(*B).Value = strconv.Itoa(a.(*A).Value)
+			return nil
+		})
+		s.Register((*B)(nil), (*C)(nil), func(b, c interface{}) (err error) {
+			c.(*C).Value, err = strconv.ParseInt(b.(*B).Value, 10, 64)
+			return err
+		})
+		s.Register(([]*A)(nil), (*[]*B)(nil), func(as, bs interface{}) error {
+			for _, a := range as.([]*A) {
+				*bs.(*[]*B) = append(*bs.(*[]*B), &B{strconv.Itoa(a.Value)})
+			}
+			return nil
+		})
+		s.Register(([]*B)(nil), (*[]*C)(nil), func(bs, cs interface{}) error {
+			for _, b := range bs.([]*B) {
+				v, _ := strconv.ParseInt(b.Value, 10, 64)
+				*cs.(*[]*C) = append(*cs.(*[]*C), &C{v})
+			}
+			return nil
+		})
+	})
+
+	t.Run("A to C", func(t *testing.T) {
+		var c C
+		err := s.Convert(&A{10}, &c)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), c.Value)
+
+		route, err := s.Route(&A{}, &C{})
+		require.NoError(t, err)
+		require.Equal(t, []TypePair{
+			{Arg: reflect.TypeOf(A{}), Out: reflect.TypeOf(B{})},
+			{Arg: reflect.TypeOf(B{}), Out: reflect.TypeOf(C{})},
+		}, route)
+	})
+	t.Run("[]*A to []*C", func(t *testing.T) {
+		var cs []*C
+		err := s.Convert([]*A{{10}, {20}}, &cs)
+		require.NoError(t, err)
+		require.Equal(t, []*C{{10}, {20}}, cs)
+	})
+	t.Run("direct", func(t *testing.T) {
+		route, err := s.Route(&A{}, &B{})
+		require.NoError(t, err)
+		require.Len(t, route, 1)
+	})
+	t.Run("no route", func(t *testing.T) {
+		_, err := s.Route(&C{}, &A{})
+		require.True(t, errors.Is(err, ErrNoConversion))
+		err = s.Convert(&C{}, &A{})
+		require.True(t, errors.Is(err, ErrNoConversion))
+	})
+}
+
+func TestGeneric(t *testing.T) {
+	s := Build(func(s *Scheme) {
+		Register(s, func(a *A, b *B) error {
+			b.Value = strconv.Itoa(a.Value)
+			return nil
+		})
+		RegisterSlice(s, func(as []*A) ([]*B, error) {
+			bs := make([]*B, len(as))
+			for i, a := range as {
+				if a.Value < 0 {
+					return bs, errors.New("negative value")
+				}
+				bs[i] = &B{strconv.Itoa(a.Value)}
+			}
+			return bs, nil
+		})
+	})
+
+	t.Run("To", func(t *testing.T) {
+		b, err := To[B](s, &A{10})
+		require.NoError(t, err)
+		require.Equal(t, "10", b.Value)
+
+		b, err = To[B](s, (*A)(nil))
+		require.NoError(t, err)
+		require.Nil(t, b)
+	})
+	t.Run("Into", func(t *testing.T) {
+		var b B
+		err := Into(s, &A{10}, &b)
+		require.NoError(t, err)
+		require.Equal(t, "10", b.Value)
+	})
+	t.Run("ToSlice", func(t *testing.T) {
+		bs, err := ToSlice[B](s, []*A{{10}, {20}})
+		require.NoError(t, err)
+		require.Equal(t, []*B{{"10"}, {"20"}}, bs)
+	})
+	t.Run("RegisterSlice (error)", func(t *testing.T) {
+		bs := []*B{{"1"}}
+		err := s.Convert([]*A{{10}, {-1}}, &bs)
+		require.EqualError(t, err, "negative value")
+		require.Equal(t, []*B{{"1"}}, bs)
+
+		bs, err = ToSlice[B](s, []*A{{-1}})
+		require.Error(t, err)
+		require.Nil(t, bs)
+	})
+}
+
+func TestSchemeShapes(t *testing.T) {
+	convertAB := func(a *A, b *B) error {
+		if a.Value < 0 {
+			return errors.New("negative")
+		}
+		b.Value = strconv.Itoa(a.Value)
+		return nil
+	}
+	s := Build(NoPanic, func(s *Scheme) {
+		RegisterValueSlice(s, convertAB)
+		RegisterMap(s, convertAB)
+		s.RegisterValidator((*B)(nil), func(obj interface{}) error {
+			if obj.(*B).Value == "0" {
+				return errors.New("zero")
+			}
+			return nil
+		})
+	})
+
+	t.Run("value slice", func(t *testing.T) {
+		var bs []B
+		err := s.Convert([]A{{10}, {20}}, &bs)
+		require.NoError(t, err)
+		require.Equal(t, []B{{"10"}, {"20"}}, bs)
+	})
+	t.Run("map", func(t *testing.T) {
+		bs, err := ToMap[B](s, map[int]*A{1: {10}, 2: nil})
+		require.NoError(t, err)
+		require.Equal(t, map[int]*B{1: {"10"}, 2: nil}, bs)
+	})
+	t.Run("error", func(t *testing.T) {
+		var bs map[string]*B
+		err := s.Convert(map[string]*A{"a": {-1}}, &bs)
+		require.EqualError(t, err, "negative")
+		require.Nil(t, bs)
+	})
+	t.Run("validator", func(t *testing.T) {
+		var bs map[string]*B
+		err := s.Convert(map[string]*A{"a": {0}}, &bs)
+		require.EqualError(t, err, "invalid output of (map[K]*conversion.A -> *map[K]*conversion.B) at key a: zero")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var bs map[int]*B
+		err := s.Convert(map[string]*A{}, &bs)
+		require.True(t, errors.Is(err, ErrInvalidTypePair))
+
+		var vs []*B
+		err = s.Convert([]A{}, &vs)
+		require.True(t, errors.Is(err, ErrInvalidTypePair))
+	})
+	t.Run("pairs", func(t *testing.T) {
+		require.True(t, s.Has([]A(nil), (*[]B)(nil)))
+		require.True(t, s.Has(map[string]*A(nil), (*map[string]*B)(nil)))
+		require.False(t, s.Has([]*A(nil), (*[]*B)(nil)))
+	})
+}
+
+func TestSchemeGraph(t *testing.T) {
+	s := Build(func(s *Scheme) {
+		Register(s, func(a *A, b *B) error { return nil })
+		Register(s, func(b *B, a *A) error { return nil })
+		RegisterSlice(s, func(as []*A) ([]*B, error) { return nil, nil })
+	})
+
+	t.Run("Pairs", func(t *testing.T) {
+		typeA, typeB := reflect.TypeOf(A{}), reflect.TypeOf(B{})
+		require.Equal(t, []TypePair{
+			{Arg: typeA, Out: typeB},
+			{Arg: typeA, Out: typeB, Slice: true},
+			{Arg: typeB, Out: typeA},
+		}, s.Pairs())
+		require.Equal(t, "[]*conversion.A -> *[]*conversion.B", s.Pairs()[1].String())
+	})
+	t.Run("Has", func(t *testing.T) {
+		require.True(t, s.Has((*A)(nil), (*B)(nil)))
+		require.True(t, s.Has(([]*A)(nil), (*[]*B)(nil)))
+		require.False(t, s.Has(([]*B)(nil), (*[]*A)(nil)))
+		require.False(t, s.Has(A{}, B{}))
+	})
+	t.Run("WriteDOT", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, s.WriteDOT(&b))
+		const pkg = "github.com/olvrng/ggen-convert/conversion"
+		expected := `
+digraph conversion {
+	"` + pkg + `.A";
+	"` + pkg + `.B";
+	"` + pkg + `.A" -> "` + pkg + `.B";
+	"` + pkg + `.A" -> "` + pkg + `.B" [style=dashed];
+	"` + pkg + `.B" -> "` + pkg + `.A";
+}
+`
+		require.Equal(t, expected[1:], b.String())
+	})
+	t.Run("WriteJSON", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, s.WriteJSON(&b))
+		var pairs []map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(b.String()), &pairs))
+		require.Len(t, pairs, 3)
+		require.Equal(t, map[string]interface{}{
+			"arg":   "github.com/olvrng/ggen-convert/conversion.A",
+			"out":   "github.com/olvrng/ggen-convert/conversion.B",
+			"slice": true,
+		}, pairs[1])
+	})
+}
+
+func TestSchemeCompose(t *testing.T) {
+	convertAB := func(prefix string) func(a *A, b *B) error {
+		return func(a *A, b *B) error {
+			b.Value = prefix + strconv.Itoa(a.Value)
+			return nil
+		}
+	}
+	base := Build(func(s *Scheme) {
+		Register(s, convertAB("base:"))
+		Register(s, func(b *B, c *C) (err error) {
+			c.Value, err = strconv.ParseInt(b.Value, 10, 64)
+			return err
+		})
+	})
+	local := Build(func(s *Scheme) {
+		Register(s, convertAB("local:"))
+		Register(s, func(b *B, a *A) (err error) {
+			a.Value, err = strconv.Atoi(b.Value)
+			return err
+		})
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		s := base.Clone().Build()
+		require.Equal(t, base.Pairs(), s.Pairs())
+		var b B
+		require.NoError(t, s.Convert(&A{10}, &b))
+		require.Equal(t, "base:10", b.Value)
+	})
+	t.Run("Clone and register", func(t *testing.T) {
+		s := base.Clone()
+		Register(s, func(b *B, a *A) (err error) {
+			a.Value, err = strconv.Atoi(b.Value)
+			return err
+		})
+		s.Build()
+		require.Len(t, s.Pairs(), len(base.Pairs())+1)
+
+		var a A
+		require.NoError(t, s.Convert(&B{"20"}, &a))
+		require.Equal(t, 20, a.Value)
+		require.Panics(t, func() { Register(s, convertAB("late:")) })
+
+		// the base scheme is not changed
+		require.Panics(t, func() { _ = base.Convert(&B{"20"}, &a) })
+	})
+	t.Run("Merge", func(t *testing.T) {
+		_, err := base.Merge(local, MergeError)
+		require.True(t, errors.Is(err, ErrConflict))
+
+		var b B
+		s, err := base.Merge(local, MergeKeep)
+		require.NoError(t, err)
+		require.Len(t, s.Pairs(), 3)
+		require.NoError(t, s.Convert(&A{10}, &b))
+		require.Equal(t, "base:10", b.Value)
+
+		s, err = base.Merge(local, MergeOverride)
+		require.NoError(t, err)
+		require.NoError(t, s.Convert(&A{10}, &b))
+		require.Equal(t, "local:10", b.Value)
+	})
+	t.Run("Extend", func(t *testing.T) {
+		child := base.Extend(func(s *Scheme) {
+			Register(s, convertAB("child:"))
+		})
+		var b B
+		require.NoError(t, child.Convert(&A{10}, &b))
+		require.Equal(t, "child:10", b.Value)
+		require.NoError(t, base.Convert(&A{10}, &b))
+		require.Equal(t, "base:10", b.Value)
+
+		var c C
+		require.NoError(t, child.Convert(&B{"20"}, &c))
+		require.Equal(t, int64(20), c.Value)
+		require.True(t, child.Has((*B)(nil), (*C)(nil)))
+		require.Len(t, child.Pairs(), 2)
+	})
+}
+
+func TestSchemeDuplicated(t *testing.T) {
+	registerAB := func(s *Scheme) {
+		Register(s, func(a *A, b *B) error { return nil })
+	}
+	registerAB2 := func(s *Scheme) {
+		s.Register((*A)(nil), (*B)(nil), func(a, b interface{}) error { return nil })
+	}
+
+	t.Run("duplicated", func(t *testing.T) {
+		_, err := TryBuild(registerAB, registerAB2)
+		require.True(t, errors.Is(err, ErrDuplicated))
+		require.Regexp(t, `^duplicated conversions \(\*conversion.A -> \*conversion.B\) registered by `+
+			`.+TestSchemeDuplicated.func1 \(.+/scheme_test.go:\d+\) and `+
+			`.+TestSchemeDuplicated.func2 \(.+/scheme_test.go:\d+\)$`, err.Error())
+		require.Panics(t, func() { Build(registerAB, registerAB2) })
+	})
+	t.Run("override", func(t *testing.T) {
+		s, err := TryBuild(registerAB, func(s *Scheme) {
+			s.Override((*A)(nil), (*B)(nil), func(a, b interface{}) error {
+				b.(*B).Value = "override"
+				return nil
+			})
+		})
+		require.NoError(t, err)
+		var b B
+		require.NoError(t, s.Convert(&A{}, &b))
+		require.Equal(t, "override", b.Value)
+	})
+	t.Run("merge conflict", func(t *testing.T) {
+		s0, s1 := Build(registerAB), Build(registerAB2)
+		_, err := s0.Merge(s1, MergeError)
+		require.Regexp(t, `func1 .+ and .+func2 `, err.Error())
+	})
+}
+
+func TestSchemeMiddleware(t *testing.T) {
+	var logs []string
+	logger := func(name string) Middleware {
+		return func(pair TypePair, next ConversionFunc) ConversionFunc {
+			return func(arg, out interface{}) error {
+				logs = append(logs, name+" "+pair.String())
+				return next(arg, out)
+			}
+		}
+	}
+	s := Build(func(s *Scheme) {
+		s.Use(logger("first"), logger("second"))
+		Register(s, func(a *A, b *B) error {
+			b.Value = strconv.Itoa(a.Value)
+			return nil
+		})
+		Register(s, func(b *B, c *C) (err error) {
+			c.Value, err = strconv.ParseInt(b.Value, 10, 64)
+			return err
+		})
+		RegisterSlice(s, func(as []*A) ([]*B, error) {
+			bs := make([]*B, len(as))
+			for i, a := range as {
+				if a != nil {
+					bs[i] = &B{"slice:" + strconv.Itoa(a.Value)}
+				}
+			}
+			return bs, nil
+		})
+		RegisterValueSlice(s, func(a *A, b *B) error {
+			b.Value = "value:" + strconv.Itoa(a.Value)
+			return nil
+		})
+	})
+
+	t.Run("Convert", func(t *testing.T) {
+		logs = nil
+		var b B
+		require.NoError(t, s.Convert(&A{10}, &b))
+		require.Equal(t, "10", b.Value)
+		require.Equal(t, []string{
+			"first *conversion.A -> *conversion.B",
+			"second *conversion.A -> *conversion.B",
+		}, logs)
+	})
+	t.Run("route", func(t *testing.T) {
+		logs = nil
+		var c C
+		require.NoError(t, s.Convert(&A{10}, &c))
+		require.Equal(t, int64(10), c.Value)
+		require.Len(t, logs, 4)
+		require.Equal(t, "first *conversion.B -> *conversion.C", logs[2])
+	})
+	t.Run("ConvertChain", func(t *testing.T) {
+		logs = nil
+		var c C
+		require.NoError(t, s.ConvertChain(&A{10}, &B{}, &c))
+		require.Equal(t, int64(10), c.Value)
+		require.Len(t, logs, 4)
+	})
+	t.Run("slice", func(t *testing.T) {
+		logs = nil
+		var bs []*B
+		require.NoError(t, s.Convert([]*A{{10}, nil}, &bs))
+		require.Equal(t, []*B{{"slice:10"}, nil}, bs)
+		require.Equal(t, []string{
+			"first []*conversion.A -> *[]*conversion.B",
+			"second []*conversion.A -> *[]*conversion.B",
+		}, logs)
+	})
+	t.Run("value slice", func(t *testing.T) {
+		logs = nil
+		var bs []B
+		require.NoError(t, s.Convert([]A{{10}}, &bs))
+		require.Equal(t, []B{{"value:10"}}, bs)
+		require.Equal(t, []string{
+			"first []conversion.A -> *[]conversion.B",
+			"second []conversion.A -> *[]conversion.B",
+			"first *conversion.A -> *conversion.B",
+			"second *conversion.A -> *conversion.B",
+		}, logs)
+	})
+}
+
+type Nested0 struct {
+	Name   string
+	Count  int32
+	A      *A
+	As     []*A
+	C      A
+	Self   *Nested0
+	Labels []string
+
+	Skipped string
+	Complex complex128
+	hidden  int
+}
+
+type Nested1 struct {
+	Name   string
+	Count  float64
+	A      *B
+	As     []*C
+	C      C
+	Self   *Nested1
+	Labels []string
+
+	Skipped int
+	Complex int
+	hidden  int
+}
+
+func TestSchemeFallback(t *testing.T) {
+	s := Build(NoPanic, ReflectFallback, func(s *Scheme) {
+		Register(s, func(a *A, b *B) error {
+			b.Value = "registered:" + strconv.Itoa(a.Value)
+			return nil
+		})
+	})
+
+	arg := &Nested0{
+		Name:    "one",
+		Count:   10,
+		A:       &A{1},
+		As:      []*A{{2}, nil},
+		C:       A{3},
+		Self:    &Nested0{Name: "two"},
+		Labels:  []string{"x"},
+		Skipped: "skipped",
+		Complex: 2 + 3i,
+		hidden:  1,
+	}
+	var out Nested1
+	require.NoError(t, s.Convert(arg, &out))
+	require.Equal(t, Nested1{
+		Name:    "one",
+		Count:   10,
+		A:       &B{"registered:1"},
+		As:      []*C{{2}, nil},
+		C:       C{3},
+		Self:    &Nested1{Name: "two"},
+		Labels:  []string{"x"},
+		Skipped: 0,
+	}, out)
+
+	var outs []*Nested1
+	require.NoError(t, s.Convert([]*Nested0{arg}, &outs))
+	require.Len(t, outs, 1)
+	require.Equal(t, out, *outs[0])
+
+	route, err := s.Route(&Nested0{}, &Nested1{})
+	require.NoError(t, err)
+	require.Len(t, route, 1)
+
+	err = s.Convert(&A{}, &Nested0{})
+	require.NoError(t, err)
+	err = Build(NoPanic).Convert(&A{}, &Nested0{})
+	require.True(t, errors.Is(err, ErrNoConversion))
+}
+
+func TestSchemeDefaulter(t *testing.T) {
+	s := Build(func(s *Scheme) {
+		Register(s, func(a *A, b *B) error {
+			if a.Value != 0 {
+				b.Value = strconv.Itoa(a.Value)
+			}
+			return nil
+		})
+		Register(s, func(b *B, c *C) (err error) {
+			c.Value, _ = strconv.ParseInt(b.Value, 10, 64)
+			return nil
+		})
+		RegisterSlice(s, func(as []*A) ([]*B, error) {
+			bs := make([]*B, len(as))
+			for i := range as {
+				bs[i] = &B{}
+			}
+			return bs, nil
+		})
+		s.RegisterDefaulter((*B)(nil), func(obj interface{}) {
+			if b := obj.(*B); b.Value == "" {
+				b.Value = "30"
+			}
+		})
+	})
+
+	t.Run("Convert", func(t *testing.T) {
+		var b B
+		require.NoError(t, s.Convert(&A{}, &b))
+		require.Equal(t, "30", b.Value)
+		require.NoError(t, s.Convert(&A{10}, &b))
+		require.Equal(t, "10", b.Value)
+	})
+	t.Run("ConvertTo", func(t *testing.T) {
+		s := s.Extend(func(s *Scheme) {
+			Register(s, func(arg *B, out *B) error {
+				*out = *arg
+				return nil
+			})
+		})
+		var b B
+		require.NoError(t, s.ConvertTo(&A{}, &b))
+		require.Equal(t, "30", b.Value)
+	})
+	t.Run("ConvertChain", func(t *testing.T) {
+		var c C
+		require.NoError(t, s.ConvertChain(&A{}, &B{}, &c))
+		require.Equal(t, int64(30), c.Value)
+	})
+	t.Run("slice", func(t *testing.T) {
+		var bs []*B
+		require.NoError(t, s.Convert([]*A{{}, {}}, &bs))
+		require.Equal(t, []*B{{"30"}, {"30"}}, bs)
+	})
+	t.Run("Default", func(t *testing.T) {
+		b := &B{}
+		s.Default(b)
+		require.Equal(t, "30", b.Value)
+
+		bs := []*B{{}, nil, {"10"}}
+		s.Default(bs)
+		require.Equal(t, []*B{{"30"}, nil, {"10"}}, bs)
+		s.Default((*B)(nil))
+	})
+	t.Run("Extend", func(t *testing.T) {
+		child := s.Extend()
+		var b B
+		require.NoError(t, child.Convert(&A{}, &b))
+		require.Equal(t, "30", b.Value)
+	})
+}
+
+func TestSchemeValidator(t *testing.T) {
+	errEmpty := errors.New("empty value")
+	s := Build(NoPanic, func(s *Scheme) {
+		Register(s, func(a *A, b *B) error {
+			if a.Value != 0 {
+				b.Value = strconv.Itoa(a.Value)
+			}
+			return nil
+		})
+		Register(s, func(b *B, c *C) (err error) {
+			c.Value, _ = strconv.ParseInt(b.Value, 10, 64)
+			return nil
+		})
+		RegisterSlice(s, func(as []*A) ([]*B, error) {
+			bs := make([]*B, len(as))
+			for i, a := range as {
+				bs[i] = &B{}
+				if a.Value != 0 {
+					bs[i].Value = strconv.Itoa(a.Value)
+				}
+			}
+			return bs, nil
+		})
+		s.RegisterValidator((*B)(nil), func(obj interface{}) error {
+			if obj.(*B).Value == "" {
+				return errEmpty
+			}
+			return nil
+		})
+	})
+
+	t.Run("Convert", func(t *testing.T) {
+		var b0, b1 B
+		require.NoError(t, s.Convert(&A{10}, &b0))
+
+		err := s.Convert(&A{}, &b1)
+		require.True(t, errors.Is(err, errEmpty))
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, reflect.TypeOf(A{}), validationErr.Pair.Arg)
+		require.Equal(t, -1, validationErr.Index)
+		require.EqualError(t, err, "invalid output of (*conversion.A -> *conversion.B): empty value")
+	})
+	t.Run("ConvertChain", func(t *testing.T) {
+		err := s.ConvertChain(&A{}, &B{}, &C{})
+		require.True(t, errors.Is(err, errEmpty))
+	})
+	t.Run("slice", func(t *testing.T) {
+		var bs []*B
+		err := s.Convert([]*A{{10}, {}}, &bs)
+		require.EqualError(t, err, "invalid output of ([]*conversion.A -> *[]*conversion.B) at index 1: empty value")
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.True(t, validationErr.Pair.Slice)
+		require.Equal(t, 1, validationErr.Index)
+	})
+}
@@ -0,0 +1,42 @@
+package conversion
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseInt parses s as a decimal integer of the given bit size.
+func ParseInt[T Integer](s string, bitSize int, name string) (T, error) {
+	v, err := strconv.ParseInt(s, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", name, err)
+	}
+	return T(v), nil
+}
+
+// ParseUint parses s as a decimal unsigned integer of the given bit size.
+func ParseUint[T Integer](s string, bitSize int, name string) (T, error) {
+	v, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", name, err)
+	}
+	return T(v), nil
+}
+
+// ParseFloat parses s as a float of the given bit size.
+func ParseFloat[T Float](s string, bitSize int, name string) (T, error) {
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", name, err)
+	}
+	return T(v), nil
+}
+
+// ParseBool parses s as a bool, accepting the values of strconv.ParseBool.
+func ParseBool[T ~bool](s string, name string) (T, error) {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("%v: %w", name, err)
+	}
+	return T(v), nil
+}
@@ -0,0 +1,26 @@
+package conversion
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// MarshalText returns the text of v as a string type.
+func MarshalText[S ~string](v encoding.TextMarshaler, name string) (S, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", name, err)
+	}
+	return S(text), nil
+}
+
+// UnmarshalText returns a new value of T which is unmarshaled from s.
+func UnmarshalText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string, name string) (out T, _ error) {
+	if err := PT(&out).UnmarshalText([]byte(s)); err != nil {
+		return out, fmt.Errorf("%v: %w", name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package conversion
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeToUnix returns t as unix seconds.
+func TimeToUnix[T ~int64](t time.Time, name string) (T, error) {
+	if t.IsZero() {
+		return 0, nil
+	}
+	if t.Nanosecond() != 0 {
+		return 0, fmt.Errorf("%v: time %v has a precision below seconds", name, t)
+	}
+	return T(t.Unix()), nil
+}
+
+// UnixToTime returns the time of the unix seconds.
+func UnixToTime(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.Unix(v, 0).UTC()
+}
+
+// TimeToUnixMilli returns t as unix milliseconds.
+func TimeToUnixMilli[T ~int64](t time.Time, name string) (T, error) {
+	if t.IsZero() {
+		return 0, nil
+	}
+	ms := t.UnixMilli()
+	if !time.UnixMilli(ms).Equal(t) {
+		return 0, fmt.Errorf("%v: time %v can not be converted to milliseconds", name, t)
+	}
+	return T(ms), nil
+}
+
+// UnixMilliToTime returns the time of the unix milliseconds.
+func UnixMilliToTime(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(v).UTC()
+}
+
+// TimeToString formats t as RFC3339, with the fractional seconds if any.
+func TimeToString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// StringToTime parses s as RFC3339.
+func StringToTime(s string, name string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v: %w", name, err)
+	}
+	return t, nil
+}
+
+// StringToTimePtr parses s as RFC3339, and returns a new pointer.
+func StringToTimePtr(s string, name string) (*time.Time, error) {
+	t, err := StringToTime(s, name)
+	return TimePtr(t), err
+}
+
+// TimePtr returns a pointer to t.
+func TimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// TimeValue returns the time of the pointer.
+func TimeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// DurationToInt returns d as an integer of the unit, such as time.Second.
+func DurationToInt[T ~int64](d time.Duration, unit time.Duration, name string) (T, error) {
+	if d%unit != 0 {
+		return 0, fmt.Errorf("%v: duration %v is not a multiple of %v", name, d, unit)
+	}
+	return T(d / unit), nil
+}
+
+// IntToDuration returns the duration of v in the unit, such as time.Second.
+func IntToDuration[T ~int64](v T, unit time.Duration, name string) (time.Duration, error) {
+	d := time.Duration(v) * unit
+	if d/unit != time.Duration(v) {
+		return 0, fmt.Errorf("%v: value %v overflows time.Duration", name, v)
+	}
+	return d, nil
+}
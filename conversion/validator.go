@@ -0,0 +1,88 @@
+package conversion
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidationError is returned when a registered validator rejects an output
+// object.
+type ValidationError struct {
+	Pair TypePair
+
+	// Index is the index of the invalid element for slice pairs, or -1.
+	Index int
+
+	// Key is the key of the invalid element for map pairs, or nil.
+	Key interface{}
+
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Key != nil {
+		return fmt.Sprintf("invalid output of (%v) at key %v: %v", e.Pair, e.Key, e.Err)
+	}
+	if e.Index >= 0 {
+		return fmt.Sprintf("invalid output of (%v) at index %v: %v", e.Pair, e.Index, e.Err)
+	}
+	return fmt.Sprintf("invalid output of (%v): %v", e.Pair, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// RegisterValidator registers the func for validating objects of the type of
+// typ, which must be a pointer such as (*T)(nil). The scheme calls it on the
+// output objects of Convert, ConvertTo and ConvertChain after applying the
+// defaulters, and on each element of slice and map outputs.
+func (s *Scheme) RegisterValidator(typ interface{}, fn func(obj interface{}) error) {
+	if s.ready {
+		panic("register too late!")
+	}
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Ptr {
+		panic("must be pointer")
+	}
+	if s.validators[t.Elem()] != nil {
+		s.errs = append(s.errs, newError(ErrDuplicated, typ, nil, "duplicated validators for %v", t))
+		return
+	}
+	s.validators[t.Elem()] = fn
+}
+
+// validate calls the registered validator on out, which is the output of the
+// conversion of pair.
+func (s *Scheme) validate(pair TypePair, out interface{}) (err error) {
+	eachObject(out, func(index int, key interface{}, v reflect.Value) bool {
+		fn := s.getValidator(v.Type().Elem())
+		if fn == nil {
+			return true
+		}
+		if err0 := fn(v.Interface()); err0 != nil {
+			err = &ValidationError{Pair: pair, Index: index, Key: key, Err: err0}
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+func (s *Scheme) getValidator(typ reflect.Type) func(obj interface{}) error {
+	for ; s != nil; s = s.parent {
+		if fn := s.validators[typ]; fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+func (s *Scheme) hasValidators() bool {
+	for ; s != nil; s = s.parent {
+		if len(s.validators) != 0 {
+			return true
+		}
+	}
+	return false
+}
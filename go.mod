@@ -1,6 +1,6 @@
 module github.com/olvrng/ggen-convert
 
-go 1.12
+go 1.18
 
 require (
 	github.com/gertd/go-pluralize v0.1.7
@@ -8,3 +8,12 @@ require (
 	github.com/stretchr/testify v1.6.1
 	golang.org/x/tools v0.0.0-20200612220849-54c614fe050c
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.2.0 // indirect
+	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
@@ -0,0 +1,274 @@
+package plugin
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/olvrng/ggen"
+)
+
+const DirectiveEnum = "convert:enum"
+const DirectiveDefault = "convert:default"
+
+// enumPolicy decides how a value of an enum which has no counterpart in the
+// other enum is converted. It is chosen with the enum option of the
+// +gen:convert directive, or with the +convert:enum directive of the output
+// enum type:
+//
+//	// +gen:convert: example.com/api -> example.com/model; enum=zero
+//
+//	// +convert:enum=default
+//	type Status int
+//
+//	const (
+//	    // +convert:default
+//	    StatusUnknown Status = iota
+//	    StatusActive
+//	)
+type enumPolicy string
+
+const (
+	// EnumError returns an error. It is the default.
+	EnumError enumPolicy = "error"
+
+	// EnumZero converts the value to the zero value.
+	EnumZero enumPolicy = "zero"
+
+	// EnumDefault converts the value to the constant of the output enum which
+	// is marked with +convert:default.
+	EnumDefault enumPolicy = "default"
+)
+
+func parseEnumPolicy(input string) (enumPolicy, error) {
+	switch s := enumPolicy(input); s {
+	case EnumError, EnumZero, EnumDefault:
+		return s, nil
+	default:
+		return "", ggen.Errorf(nil, "invalid enum policy (%v)", input)
+	}
+}
+
+// enumType is a named basic type with the constants declared with it in its
+// package.
+type enumType struct {
+	named  *types.Named
+	consts []*types.Const
+}
+
+var cacheEnums = map[*types.Named]*enumType{}
+
+// lookupEnum returns the enum of the type, or nil if the type is not a named
+// basic type with declared constants.
+func lookupEnum(typ types.Type) *enumType {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if enum, ok := cacheEnums[named]; ok {
+		return enum
+	}
+	var enum *enumType
+	if _, ok := named.Underlying().(*types.Basic); ok && named.Obj().Pkg() != nil {
+		scope := named.Obj().Pkg().Scope()
+		var consts []*types.Const
+		for _, name := range scope.Names() {
+			if c, ok := scope.Lookup(name).(*types.Const); ok && c.Exported() && types.Identical(c.Type(), named) {
+				consts = append(consts, c)
+			}
+		}
+		if len(consts) != 0 {
+			sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+			enum = &enumType{named: named, consts: consts}
+		}
+	}
+	cacheEnums[named] = enum
+	return enum
+}
+
+// key returns the normalized name of a constant, without the name of the type
+// as prefix. Two constants are matched when their keys are equal, such as
+// api.StatusActive and model.STATUS_ACTIVE.
+func (e *enumType) key(c *types.Const) string {
+	name := c.Name()
+	prefix := e.named.Obj().Name()
+	if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+		name = name[len(prefix):]
+	}
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// policy returns the policy in the +convert:enum directive of the enum, or the
+// one of the package.
+func (e *enumType) policy() (enumPolicy, error) {
+	for _, d := range currentEngine.GetDirectives(e.named.Obj()) {
+		if d.Cmd == DirectiveEnum {
+			return parseEnumPolicy(d.Arg)
+		}
+	}
+	if currentOptions.enum != "" {
+		return currentOptions.enum, nil
+	}
+	return EnumError, nil
+}
+
+// hasDirective reports whether the enum is marked with +convert:enum.
+func (e *enumType) hasDirective() bool {
+	for _, d := range currentEngine.GetDirectives(e.named.Obj()) {
+		if d.Cmd == DirectiveEnum {
+			return true
+		}
+	}
+	return false
+}
+
+// isEnumPair reports whether two named types are converted as enums. A named
+// type may only have a few constants, such as a zero or a max value, so the
+// types must be marked with +convert:enum on one side, or have more than one
+// matching constant. Otherwise they are cast.
+func isEnumPair(arg, out *enumType) bool {
+	keys := make(map[string]bool)
+	for _, c := range out.consts {
+		keys[out.key(c)] = true
+	}
+	matches := 0
+	for _, c := range arg.consts {
+		if keys[arg.key(c)] {
+			matches++
+		}
+	}
+	if arg.hasDirective() || out.hasDirective() {
+		return matches > 0
+	}
+	return matches > 1
+}
+
+// defaultConst returns the constant marked with +convert:default.
+func (e *enumType) defaultConst() *types.Const {
+	for _, c := range e.consts {
+		for _, d := range currentEngine.GetDirectives(c) {
+			if d.Cmd == DirectiveDefault {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// enumConv is a conversion between two enums, which is generated as a func
+// after the conversions of the package.
+type enumConv struct {
+	arg, out *enumType
+	name     string
+}
+
+// enumConvs are the conversions between enums used by the package being
+// generated.
+var enumConvs []*enumConv
+
+// renderEnumConversion renders the call to the func which converts between
+// two enums by the names of their constants.
+func renderEnumConversion(in, out *types.Var, prefix string) string {
+	argEnum, outEnum := lookupEnum(in.Type()), lookupEnum(out.Type())
+	if argEnum == nil || outEnum == nil || argEnum.named == outEnum.named || !isEnumPair(argEnum, outEnum) {
+		return ""
+	}
+	p := currentPrinter
+	name := "convert_" + strings.ReplaceAll(p.TypeString(argEnum.named), ".", "_") +
+		"_" + strings.ReplaceAll(p.TypeString(outEnum.named), ".", "_")
+	found := false
+	for _, conv := range enumConvs {
+		if conv.arg == argEnum && conv.out == outEnum {
+			found = true
+		}
+	}
+	if !found {
+		enumConvs = append(enumConvs, &enumConv{arg: argEnum, out: outEnum, name: name})
+	}
+	lastComment = "// enum conversion"
+	lastError = true
+	return name + "(" + prefix + "." + in.Name() + ")"
+}
+
+// generateEnumConverts generates the funcs which convert between enums. The
+// constants which have no counterpart are reported, and converted with the
+// policy of the output enum.
+func generateEnumConverts(p ggen.Printer) error {
+	if len(enumConvs) == 0 {
+		return nil
+	}
+	w(p, "//-- convert enums --//\n")
+	for _, conv := range enumConvs {
+		if err := generateEnumConvert(p, conv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateEnumConvert(p ggen.Printer, conv *enumConv) error {
+	argType, outType := p.TypeString(conv.arg.named), p.TypeString(conv.out.named)
+	policy, err := conv.out.policy()
+	if err != nil {
+		return ggen.Errorf(err, "%v: %v", outType, err)
+	}
+	qualify := func(c *types.Const) string {
+		if qualifier := p.Qualifier(c.Pkg()); qualifier != "" {
+			return qualifier + "." + c.Name()
+		}
+		return c.Name()
+	}
+	var unknown string
+	switch policy {
+	case EnumError:
+		p.Import("fmt", "fmt")
+		unknown = fmt.Sprintf("return %v, fmt.Errorf(\"can not convert %v(%%v) to %v\", arg)",
+			renderZero(conv.out.named), argType, outType)
+	case EnumZero:
+		unknown = fmt.Sprintf("return %v, nil", renderZero(conv.out.named))
+	case EnumDefault:
+		c := conv.out.defaultConst()
+		if c == nil {
+			return ggen.Errorf(nil, "%v: no constant is marked with +%v", outType, DirectiveDefault)
+		}
+		unknown = fmt.Sprintf("return %v, nil", qualify(c))
+	}
+
+	outConsts := make(map[string]*types.Const)
+	for _, c := range conv.out.consts {
+		if key := conv.out.key(c); outConsts[key] == nil {
+			outConsts[key] = c
+		}
+	}
+
+	w(p, "\nfunc %v(arg %v) (%v, error) {\n", conv.name, argType, outType)
+	w(p, "switch arg {\n")
+	values := make(map[string]bool)
+	for _, c := range conv.arg.consts {
+		outConst := outConsts[conv.arg.key(c)]
+		if outConst == nil {
+			reportConst(c, "no counterpart in %v", outType)
+			w(p, "// %v: no counterpart in %v\n", c.Name(), outType)
+			continue
+		}
+		// constants with the same value are converted by the first one
+		if value := c.Val().ExactString(); !values[value] {
+			values[value] = true
+			w(p, "case %v:\nreturn %v, nil\n", qualify(c), qualify(outConst))
+		}
+	}
+	w(p, "default:\n%v\n}\n}\n", unknown)
+	return nil
+}
+
+// reportConst reports a constant of an enum which has no counterpart. It is an
+// error in strict mode.
+func reportConst(c *types.Const, format string, args ...interface{}) {
+	msg := fmt.Sprintf("%v: %v: %v", objPosition(c), c.Name(), fmt.Sprintf(format, args...))
+	if currentOptions.strict {
+		strictErrors = append(strictErrors, msg)
+		return
+	}
+	ll.V(0).Printf("warning: %v", msg)
+}
@@ -0,0 +1,260 @@
+package plugin
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/olvrng/ggen"
+)
+
+const DirectiveField = "convert:field"
+const DirectiveIgnore = "convert:ignore"
+
+// TagField is the struct tag for renaming a field, as an alternative to the
+// +convert:field directive.
+const TagField = "convert"
+
+var reFieldName = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*(\.[A-Z][A-Za-z0-9_]*)*$`)
+
+// fieldNames maps the names of the fields of an annotated struct to the names
+// of the fields of the struct which it is converted from or to.
+type fieldNames map[string]string
+
+// get returns the name of the field of the other struct which is matched with
+// the given field, which is the same name if the field is not renamed.
+func (names fieldNames) get(name string) string {
+	if other, ok := names[name]; ok {
+		return other
+	}
+	return name
+}
+
+// parseFieldNames reads the +convert:field directives and the convert tags of
+// the fields of obj. The name can be a path to a field of a nested struct:
+//
+//	type User struct {
+//	    // +convert:field=UserID
+//	    ID string
+//
+//	    Name string `convert:"FullName"`
+//
+//	    City string `convert:"Address.City"`
+//	}
+func parseFieldNames(ng ggen.Engine, obj types.Object) (fieldNames, error) {
+	st := validateStruct(obj)
+	var names fieldNames
+	for i, n := 0, st.NumFields(); i < n; i++ {
+		field := st.Field(i)
+		var name string
+		for _, d := range getFieldDirectives(ng, field) {
+			if d.Cmd != DirectiveField {
+				continue
+			}
+			if name != "" {
+				return nil, ggen.Errorf(nil, "%v.%v: duplicated directive %v", obj.Name(), field.Name(), d.Raw)
+			}
+			name = d.Arg
+		}
+		if tag, ok := reflect.StructTag(st.Tag(i)).Lookup(TagField); ok {
+			if name != "" && name != tag {
+				return nil, ggen.Errorf(nil, "%v.%v: directive (%v) and tag (%v) do not match", obj.Name(), field.Name(), name, tag)
+			}
+			name = tag
+		}
+		if name == "" {
+			continue
+		}
+		if !reFieldName.MatchString(name) {
+			return nil, ggen.Errorf(nil, "%v.%v: invalid field name (%v)", obj.Name(), field.Name(), name)
+		}
+		if names == nil {
+			names = make(fieldNames)
+		}
+		names[field.Name()] = name
+	}
+	return names, nil
+}
+
+// getFieldDirectives returns the directives in the doc comment and in the
+// trailing comment of the field.
+func getFieldDirectives(ng ggen.Engine, field *types.Var) []ggen.Directive {
+	comment := ng.GetComment(field)
+	directives := comment.Directives
+	if comment.Comment == nil {
+		return directives
+	}
+	for _, line := range comment.Comment.List {
+		if !strings.HasPrefix(line.Text, "// +") {
+			continue
+		}
+		ds, err := ggen.ParseDirective(line.Text[len("// "):])
+		if err != nil {
+			ll.V(3).Printf("error while parsing comment of field %v: %v", field.Name(), err)
+			continue
+		}
+		directives = append(directives, ds...)
+	}
+	return directives
+}
+
+// hasFieldDirective reports whether one of the fields has the directive.
+func hasFieldDirective(in, out *types.Var, cmd string) bool {
+	for _, field := range []*types.Var{out, in} {
+		for _, d := range getFieldDirectives(currentEngine, field) {
+			if d.Cmd == cmd {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateFieldNames checks that the renamed fields of obj exist in other, and
+// that no two fields of obj are matched with the same field.
+func validateFieldNames(names fieldNames, obj, other types.Object) error {
+	if len(names) == 0 {
+		return nil
+	}
+	st, otherSt := validateStruct(obj), validateStruct(other)
+	matched := make(map[string]string)
+	for i, n := 0, st.NumFields(); i < n; i++ {
+		name := st.Field(i).Name()
+		otherName := names.get(name)
+		if prev, ok := matched[otherName]; ok {
+			return ggen.Errorf(nil, "%v.%v and %v.%v are both matched with %v.%v",
+				obj.Name(), prev, obj.Name(), name, other.Name(), otherName)
+		}
+		matched[otherName] = name
+		if _, ok := names[name]; ok && lookupPath(otherSt, otherName) == nil {
+			return ggen.Errorf(nil, "%v.%v: field %v not found in %v", obj.Name(), name, otherName, other.Name())
+		}
+	}
+	return nil
+}
+
+func lookupField(st *types.Struct, name string) *types.Var {
+	for i, n := 0, st.NumFields(); i < n; i++ {
+		if field := st.Field(i); field.Name() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// isPath reports whether the name is a path to a field of a nested struct,
+// such as Address.City.
+func isPath(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// lookupPath returns the fields in the path, such as Address.City, or nil if
+// the path is not found. All fields except the last one must be nested
+// structs.
+func lookupPath(st *types.Struct, path string) []*types.Var {
+	var result []*types.Var
+	for _, name := range strings.Split(path, ".") {
+		if st == nil {
+			return nil
+		}
+		field := lookupField(st, name)
+		if field == nil {
+			return nil
+		}
+		result = append(result, field)
+		st = nil
+		if named := nestedStruct(field.Type()); named != nil {
+			st = named.Underlying().(*types.Struct)
+		}
+	}
+	return result
+}
+
+// nestedStruct returns the named struct type of a field of type T or *T, which
+// can be flattened.
+func nestedStruct(typ types.Type) *types.Named {
+	named, _ := skipPointer(typ).(*types.Named)
+	if named == nil {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	return named
+}
+
+var cacheIgnored = map[*types.Var]bool{}
+
+// isIgnored reports whether the field is marked with +convert:ignore. Ignored
+// fields are not matched with any field, and are left unchanged.
+func isIgnored(field *types.Var) bool {
+	if ignored, ok := cacheIgnored[field]; ok {
+		return ignored
+	}
+	ignored := false
+	for _, d := range getFieldDirectives(currentEngine, field) {
+		if d.Cmd == DirectiveIgnore {
+			ignored = true
+		}
+	}
+	cacheIgnored[field] = ignored
+	return ignored
+}
+
+// strictErrors are the fields which are not converted in the package being
+// generated, reported in strict mode.
+var strictErrors []string
+
+// reportField records a field which is not converted, if the package is
+// generated in strict mode. The name is the field with its struct, such as
+// User.Name.
+func reportField(field *types.Var, name string, format string, args ...interface{}) {
+	if !currentOptions.strict || isIgnored(field) {
+		return
+	}
+	msg := fmt.Sprintf("%v: %v: %v", objPosition(field), name, fmt.Sprintf(format, args...))
+	strictErrors = append(strictErrors, msg)
+}
+
+func objPosition(obj types.Object) string {
+	pkg := currentEngine.GetPackage(obj)
+	if pkg == nil || pkg.Fset == nil {
+		return obj.Pkg().Path()
+	}
+	return pkg.Fset.Position(obj.Pos()).String()
+}
+
+// checkStrict returns an error listing the fields which are not converted.
+func checkStrict(pkgPath string) error {
+	if len(strictErrors) == 0 {
+		return nil
+	}
+	return ggen.Errorf(nil, "convert package %v (strict): %v fields are not converted:\n\t%v",
+		pkgPath, len(strictErrors), strings.Join(strictErrors, "\n\t"))
+}
+
+// IsNested reports whether the field is flattened or unflattened.
+func (f fieldConvert) IsNested() bool {
+	return len(f.ArgPath) != 0 || len(f.OutPath) != 0
+}
+
+// target returns the expression of the output field, such as out.Home.City.
+func (f fieldConvert) target() string {
+	return "out." + renderPath(append(f.OutPath[:len(f.OutPath):len(f.OutPath)], f.Out))
+}
+
+// name returns the name of the output field in the errors, such as
+// Person.Home.City.
+func (f fieldConvert) name() string {
+	return f.OutObj.Name() + "." + renderPath(append(f.OutPath[:len(f.OutPath):len(f.OutPath)], f.Out))
+}
+
+// argField returns the field of the arg struct which is converted.
+func (f fieldConvert) argField() *types.Var {
+	if len(f.ArgPath) != 0 {
+		return f.ArgPath[0]
+	}
+	return f.Arg
+}
@@ -0,0 +1,341 @@
+package plugin
+
+import (
+	"go/types"
+	"strings"
+	"unicode"
+
+	"github.com/olvrng/ggen"
+)
+
+const DirectiveMatch = "convert:match"
+
+// matchStrategy decides which fields of two structs are matched together. It
+// is chosen with the match option of the +gen:convert directive, or with the
+// +convert:match directive of a type:
+//
+//	// +gen:convert: example.com/api -> example.com/model; match=fold
+//
+//	// +convert:type=model.User
+//	// +convert:match=snake
+//	type User struct { ... }
+type matchStrategy string
+
+const (
+	// MatchExact matches fields with the same name. It is the default.
+	MatchExact matchStrategy = "exact"
+
+	// MatchFold matches fields whose names are equal under case-folding, such
+	// as UserId and UserID.
+	MatchFold matchStrategy = "fold"
+
+	// MatchSnake matches fields whose names have the same words, ignoring the
+	// case and the underscores, such as User_Name and UserName.
+	MatchSnake matchStrategy = "snake"
+
+	// MatchInitialism matches fields whose names are the same after writing
+	// the common initialisms in upper case, such as UserId and UserID, or
+	// ImageUrl and ImageURL.
+	MatchInitialism matchStrategy = "initialism"
+)
+
+func parseMatchStrategy(input string) (matchStrategy, error) {
+	switch s := matchStrategy(input); s {
+	case MatchExact, MatchFold, MatchSnake, MatchInitialism:
+		return s, nil
+	default:
+		return "", ggen.Errorf(nil, "invalid match strategy (%v)", input)
+	}
+}
+
+// parseTypeMatch returns the strategy in the +convert:match directive of obj,
+// or an empty string.
+func parseTypeMatch(obj types.Object, directives []ggen.Directive) (match matchStrategy, err error) {
+	for _, d := range directives {
+		if d.Cmd != DirectiveMatch {
+			continue
+		}
+		if match != "" {
+			return "", ggen.Errorf(nil, "%v: duplicated directive %v", obj.Name(), d.Raw)
+		}
+		if match, err = parseMatchStrategy(d.Arg); err != nil {
+			return "", ggen.Errorf(err, "%v: %v", obj.Name(), err)
+		}
+	}
+	return match, nil
+}
+
+// getMatch returns the strategy of the type, or the one of the package.
+func (g objGen) getMatch() matchStrategy {
+	switch {
+	case g.match != "":
+		return g.match
+	case currentOptions.match != "":
+		return currentOptions.match
+	default:
+		return MatchExact
+	}
+}
+
+// key returns the normalized name of a field. Two fields are matched when
+// their keys are equal.
+func (m matchStrategy) key(name string) string {
+	switch m {
+	case MatchFold:
+		return strings.ToLower(name)
+	case MatchSnake:
+		return strings.ToLower(strings.Join(splitWords(name), "_"))
+	case MatchInitialism:
+		words := splitWords(name)
+		for i, word := range words {
+			if upper := strings.ToUpper(word); commonInitialisms[upper] {
+				words[i] = upper
+			}
+		}
+		return strings.Join(words, "")
+	default:
+		return name
+	}
+}
+
+// splitWords splits a name in camel case or snake case into words. A sequence
+// of upper case letters is a word, except its last letter when it is followed
+// by a lower case letter: "ImageURLPath" is split into "Image", "URL", "Path".
+func splitWords(name string) (words []string) {
+	runes := []rune(name)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(runes))
+	return words
+}
+
+// commonInitialisms is the list of initialisms from golint.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// matchFields returns the fields of out with the fields of arg which are
+// matched with them, after applying the renames of the structs. Ignored fields
+// are never matched. It is an error when two fields are matched with the same
+// field.
+//
+// A field which is not matched directly can be matched with a field of a
+// nested struct of arg (flattening), or can be a nested struct whose fields
+// are matched with the fields of arg (unflattening). The paths are given by
+// +convert:field directives, or found by concatenating the names of the
+// nested fields:
+//
+//	type User struct { Address *Address }
+//	type Address struct { City string }
+//
+//	// +convert:type=User
+//	type UserInfo struct {
+//	    AddressCity string // matches User.Address.City
+//	}
+func matchFields(out, arg types.Object, outNames, argNames fieldNames, match matchStrategy) ([]fieldConvert, error) {
+	outSt, argSt := validateStruct(out), validateStruct(arg)
+	argFields := make(map[string][]*types.Var)
+	for i, n := 0, argSt.NumFields(); i < n; i++ {
+		field := argSt.Field(i)
+		if isIgnored(field) || isPath(argNames.get(field.Name())) {
+			continue
+		}
+		key := match.key(argNames.get(field.Name()))
+		argFields[key] = append(argFields[key], field)
+	}
+
+	// match the fields with the same names first
+	fields := make([]fieldConvert, outSt.NumFields())
+	outFields := make(map[string]*types.Var)
+	used := make(map[*types.Var]bool)
+	for i, n := 0, outSt.NumFields(); i < n; i++ {
+		field := outSt.Field(i)
+		fields[i] = fieldConvert{Out: field, OutObj: out, IsIgnored: isIgnored(field)}
+		name := outNames.get(field.Name())
+		if fields[i].IsIgnored || isPath(name) {
+			continue
+		}
+		key := match.key(name)
+		candidates := argFields[key]
+		switch {
+		case len(candidates) == 0:
+			continue
+		case len(candidates) > 1:
+			return nil, ggen.Errorf(nil, "%v.%v and %v.%v are both matched with %v.%v (match=%v)",
+				arg.Name(), candidates[0].Name(), arg.Name(), candidates[1].Name(), out.Name(), field.Name(), match)
+		case outFields[key] != nil:
+			return nil, ggen.Errorf(nil, "%v.%v and %v.%v are both matched with %v.%v (match=%v)",
+				out.Name(), outFields[key].Name(), out.Name(), field.Name(), arg.Name(), candidates[0].Name(), match)
+		}
+		outFields[key] = field
+		fields[i].Arg = candidates[0]
+		used[candidates[0]] = true
+	}
+
+	// then the nested fields
+	var result []fieldConvert
+	for _, field := range fields {
+		if field.Arg != nil || field.IsIgnored {
+			result = append(result, field)
+			continue
+		}
+		nested, err := matchNestedFields(field.Out, out, arg, outNames, argNames, match, used)
+		if err != nil {
+			return nil, err
+		}
+		if len(nested) == 0 {
+			result = append(result, field)
+		}
+		result = append(result, nested...)
+	}
+	return result, nil
+}
+
+func matchNestedFields(
+	outField *types.Var, out, arg types.Object,
+	outNames, argNames fieldNames, match matchStrategy, used map[*types.Var]bool,
+) ([]fieldConvert, error) {
+	outSt, argSt := validateStruct(out), validateStruct(arg)
+
+	// flattening with +convert:field=Address.City
+	if name := outNames.get(outField.Name()); isPath(name) {
+		path := lookupPath(argSt, name)
+		if path == nil {
+			return nil, ggen.Errorf(nil, "%v.%v: field %v not found in %v", out.Name(), outField.Name(), name, arg.Name())
+		}
+		last := len(path) - 1
+		return []fieldConvert{{Arg: path[last], ArgPath: path[:last], Out: outField, OutObj: out}}, nil
+	}
+
+	// unflattening with +convert:field=Address.City
+	var result []fieldConvert
+	for i, n := 0, argSt.NumFields(); i < n; i++ {
+		argField := argSt.Field(i)
+		name := argNames.get(argField.Name())
+		if !isPath(name) || !strings.HasPrefix(name, outField.Name()+".") {
+			continue
+		}
+		path := lookupPath(outSt, name)
+		if path == nil {
+			return nil, ggen.Errorf(nil, "%v.%v: field %v not found in %v", arg.Name(), argField.Name(), name, out.Name())
+		}
+		used[argField] = true
+		result = append(result, newUnflattenField(argField, out, path))
+	}
+	if outField.Embedded() {
+		return result, nil
+	}
+
+	// flattening by concatenated names
+	if len(result) == 0 {
+		key := match.key(outField.Name())
+		var candidates [][]*types.Var
+		walkNestedFields(argSt, nil, func(name string, path []*types.Var) {
+			if match.key(name) == key {
+				candidates = append(candidates, path)
+			}
+		})
+		switch {
+		case len(candidates) == 1:
+			path := candidates[0]
+			last := len(path) - 1
+			return []fieldConvert{{Arg: path[last], ArgPath: path[:last], Out: outField, OutObj: out}}, nil
+		case len(candidates) > 1:
+			return nil, ggen.Errorf(nil, "%v.%v is matched with both %v and %v (match=%v)",
+				out.Name(), outField.Name(), renderPath(candidates[0]), renderPath(candidates[1]), match)
+		}
+	}
+
+	// unflattening by concatenated names
+	argFields := make(map[string]*types.Var)
+	for i, n := 0, argSt.NumFields(); i < n; i++ {
+		field := argSt.Field(i)
+		if !used[field] && !isIgnored(field) {
+			argFields[match.key(argNames.get(field.Name()))] = field
+		}
+	}
+	walkNestedFields(outSt, nil, func(name string, path []*types.Var) {
+		if path[0] != outField {
+			return
+		}
+		if argField := argFields[match.key(name)]; argField != nil {
+			used[argField] = true
+			result = append(result, newUnflattenField(argField, out, path))
+		}
+	})
+	return result, nil
+}
+
+func newUnflattenField(argField *types.Var, out types.Object, path []*types.Var) fieldConvert {
+	last := len(path) - 1
+	return fieldConvert{
+		Arg:     argField,
+		Out:     path[last],
+		OutObj:  out,
+		OutPath: path[:last],
+	}
+}
+
+// maxNestedDepth limits the depth of the nested structs which are flattened
+// automatically.
+const maxNestedDepth = 3
+
+// walkNestedFields calls fn with each field of the nested structs of st, with
+// the concatenated name and the path of the field. Embedded and ignored fields
+// are skipped.
+func walkNestedFields(st *types.Struct, path []*types.Var, fn func(name string, path []*types.Var)) {
+	if len(path) >= maxNestedDepth {
+		return
+	}
+	for i, n := 0, st.NumFields(); i < n; i++ {
+		field := st.Field(i)
+		if !field.Exported() || field.Embedded() || isIgnored(field) {
+			continue
+		}
+		fieldPath := append(path[:len(path):len(path)], field)
+		if len(path) != 0 {
+			fn(renderPathName(fieldPath), fieldPath)
+		}
+		if named := nestedStruct(field.Type()); named != nil {
+			walkNestedFields(named.Underlying().(*types.Struct), fieldPath, fn)
+		}
+	}
+}
+
+func renderPathName(path []*types.Var) string {
+	var b strings.Builder
+	for _, field := range path {
+		b.WriteString(field.Name())
+	}
+	return b.String()
+}
+
+func renderPath(path []*types.Var) string {
+	names := make([]string, len(path))
+	for i, field := range path {
+		names[i] = field.Name()
+	}
+	return strings.Join(names, ".")
+}
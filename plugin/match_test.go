@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/olvrng/ggen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"User", "ID"}, splitWords("UserID"))
+	assert.Equal(t, []string{"User", "Id"}, splitWords("UserId"))
+	assert.Equal(t, []string{"Image", "URL", "Path"}, splitWords("ImageURLPath"))
+	assert.Equal(t, []string{"Alt", "Text"}, splitWords("Alt_Text"))
+	assert.Equal(t, []string{"Address2"}, splitWords("Address2"))
+	assert.Equal(t, []string{"ID"}, splitWords("ID"))
+}
+
+func TestMatchStrategy(t *testing.T) {
+	assert.Equal(t, MatchExact.key("UserId"), "UserId")
+	assert.Equal(t, MatchFold.key("UserId"), MatchFold.key("UserID"))
+	assert.Equal(t, MatchSnake.key("User_Name"), MatchSnake.key("UserName"))
+	assert.Equal(t, MatchSnake.key("image_url"), MatchSnake.key("ImageURL"))
+	assert.Equal(t, MatchInitialism.key("ImageUrl"), MatchInitialism.key("ImageURL"))
+	assert.Equal(t, MatchInitialism.key("UserId"), "UserID")
+	assert.NotEqual(t, MatchInitialism.key("Username"), MatchInitialism.key("UserName"))
+}
+
+func TestParsePkgOptions(t *testing.T) {
+	pkgs, input := splitOptions("example.com/api -> example.com/model; strict, match=snake")
+	assert.Equal(t, "example.com/api -> example.com/model", pkgs)
+
+	var opts pkgOptions
+	assert.NoError(t, parsePkgOptions(input, &opts))
+	assert.Equal(t, pkgOptions{strict: true, match: MatchSnake}, opts)
+
+	assert.NoError(t, parsePkgOptions("enum=zero", &opts))
+	assert.Equal(t, EnumZero, opts.enum)
+	assert.NoError(t, parsePkgOptions("time", &opts))
+	assert.Equal(t, TimeSeconds, opts.time)
+	assert.NoError(t, parsePkgOptions("time=millis", &opts))
+	assert.Equal(t, TimeMillis, opts.time)
+	assert.NoError(t, parsePkgOptions("nil=keep", &opts))
+	assert.Equal(t, NilKeep, opts.nil)
+	assert.NoError(t, parsePkgOptions("checked", &opts))
+	assert.True(t, opts.checked)
+	assert.NoError(t, parsePkgOptions("strconv", &opts))
+	assert.True(t, opts.strconv)
+
+	assert.Error(t, parsePkgOptions("match=unknown", &opts))
+	assert.Error(t, parsePkgOptions("enum=unknown", &opts))
+	assert.Error(t, parsePkgOptions("time=hours", &opts))
+	assert.Error(t, parsePkgOptions("nil=unknown", &opts))
+	assert.Error(t, parsePkgOptions("unknown", &opts))
+}
+
+// noCommentEngine is an engine where no field has directives.
+type noCommentEngine struct {
+	ggen.Engine
+}
+
+func (noCommentEngine) GetComment(ggen.Positioner) ggen.Comment {
+	return ggen.Comment{}
+}
+
+func TestMatchFieldsCollision(t *testing.T) {
+	const src = `package match
+
+type User struct {
+	UserID  string
+	User_Id string
+}
+
+type UserRow struct {
+	User_ID string
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "match.go", src, 0)
+	require.NoError(t, err)
+	pkg, err := new(types.Config).Check("example.com/match", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	defer func(ng ggen.Engine) { currentEngine = ng }(currentEngine)
+	currentEngine = noCommentEngine{}
+	user, row := pkg.Scope().Lookup("User"), pkg.Scope().Lookup("UserRow")
+
+	// two input fields are matched with the same output field
+	_, err = matchFields(row, user, nil, nil, MatchSnake)
+	assert.EqualError(t, err, "User.UserID and User.User_Id are both matched with UserRow.User_ID (match=snake)")
+
+	// two output fields are matched with the same input field
+	_, err = matchFields(user, row, nil, nil, MatchSnake)
+	assert.EqualError(t, err, "User.UserID and User.User_Id are both matched with UserRow.User_ID (match=snake)")
+}
@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"go/types"
+)
+
+// nullValueField returns the value field of a struct which has a Valid bool
+// field and a single value field, such as sql.NullString or sql.NullTime, or
+// nil if the type is not such a struct.
+func nullValueField(typ types.Type) *types.Var {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 2 {
+		return nil
+	}
+	var value *types.Var
+	hasValid := false
+	for i := 0; i < 2; i++ {
+		field := st.Field(i)
+		switch {
+		case !field.Exported() || field.Embedded():
+			return nil
+		case field.Name() == "Valid":
+			basic, _ := field.Type().(*types.Basic)
+			hasValid = basic != nil && basic.Kind() == types.Bool
+		default:
+			value = field
+		}
+	}
+	if !hasValid {
+		return nil
+	}
+	return value
+}
+
+// canConvertNull reports whether the value of a null type can be converted to
+// or from the type.
+func canConvertNull(value, typ types.Type) bool {
+	return types.Identical(value, typ) || canCast(checkBasicType(value), checkBasicType(typ))
+}
+
+// renderNullConversion renders the conversion between a null type, such as
+// sql.NullString, and a field of type T or *T. An invalid value is converted
+// to the zero value or nil, and a nil pointer is converted to an invalid
+// value. A value is always converted to a valid one.
+func renderNullConversion(in, out *types.Var, prefix, name string) string {
+	value := prefix + "." + in.Name()
+	checked := isChecked(in, out)
+	if inValue := nullValueField(in.Type()); inValue != nil && nullValueField(out.Type()) == nil {
+		outElem, outPtr := pointerElem(out.Type())
+		if !canConvertNull(inValue.Type(), outElem) {
+			return ""
+		}
+		args := value + "." + inValue.Name() + ", " + value + ".Valid"
+		if check := renderCheckedFunc(inValue.Type(), outElem, name); checked && check != "" {
+			lastComment = "// checked conversion"
+			lastError = true
+			if !outPtr {
+				return renderCheckedCast("conversion.NullValue("+args+")", inValue.Type(), outElem, name)
+			}
+			return "conversion.ConvertPointerErr(conversion.NullPointer(" + args + "), " + check + ")"
+		}
+		lastComment = "// null conversion"
+		lastError = false
+		if !outPtr {
+			return renderCast("conversion.NullValue("+args+")", inValue.Type(), outElem)
+		}
+		result := "conversion.NullPointer(" + args + ")"
+		if !types.Identical(inValue.Type(), outElem) {
+			p := currentPrinter
+			result = "conversion.ConvertPointer(" + result + ", func(v " + p.TypeString(inValue.Type()) + ") " +
+				p.TypeString(outElem) + " { return " + renderCast("v", inValue.Type(), outElem) + " })"
+		}
+		return result
+	}
+	if outValue := nullValueField(out.Type()); outValue != nil && nullValueField(in.Type()) == nil {
+		inElem, inPtr := pointerElem(in.Type())
+		if !canConvertNull(inElem, outValue.Type()) {
+			return ""
+		}
+		valid := "true"
+		if inPtr {
+			value, valid = "conversion.PointerValue("+value+")", value+" != nil"
+		}
+		if check := renderCheckedFunc(inElem, outValue.Type(), name); checked && check != "" {
+			p := currentPrinter
+			outType := p.TypeString(out.Type())
+			lastComment = "// checked conversion"
+			lastError = true
+			return "conversion.ConvertNull(" + value + ", " + valid + ", " + check + ", " +
+				"func(v " + p.TypeString(outValue.Type()) + ", valid bool) " + outType + " { return " +
+				outType + "{" + outValue.Name() + ": v, Valid: valid} })"
+		}
+		lastComment = "// null conversion"
+		lastError = false
+		return currentPrinter.TypeString(out.Type()) + "{" + outValue.Name() + ": " +
+			renderCast(value, inElem, outValue.Type()) + ", Valid: " + valid + "}"
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"go/types"
+	"strconv"
+)
+
+// DirectiveChecked enables the checked conversions of a numeric field, as the
+// checked option of the +gen:convert directive does for the package:
+//
+//	// +gen:convert: example.com/api -> example.com/model; checked
+//
+//	type User struct {
+//	    Age int64 // +convert:checked
+//	}
+const DirectiveChecked = "convert:checked"
+
+// isChecked reports whether the conversion between the fields is checked.
+func isChecked(in, out *types.Var) bool {
+	return currentOptions.checked || hasFieldDirective(in, out, DirectiveChecked)
+}
+
+// renderCheckedConversion renders the conversion between numeric fields which
+// returns an error when the value is out of the range of the output type, for
+// narrowing, sign-changing and float to integer conversions.
+func renderCheckedConversion(in, out *types.Var, prefix, name string) string {
+	if !isChecked(in, out) {
+		return ""
+	}
+	result := renderCheckedCast(prefix+"."+in.Name(), in.Type(), out.Type(), name)
+	if result == "" {
+		return ""
+	}
+	lastComment = "// checked conversion"
+	lastError = true
+	return result
+}
+
+// renderCheckedCast renders the checked conversion of expr from in to out,
+// which returns an error, or an empty string if all values of in can be
+// converted to out.
+func renderCheckedCast(expr string, in, out types.Type, name string) string {
+	inBasic, outBasic := checkBasicType(in), checkBasicType(out)
+	if inBasic == nil || outBasic == nil {
+		return ""
+	}
+	helper := checkedHelper(inBasic, outBasic)
+	if helper == "" {
+		return ""
+	}
+	return "conversion." + helper + "[" + currentPrinter.TypeString(out) + "](" + expr + ", " + strconv.Quote(name) + ")"
+}
+
+// renderCheckedFunc renders the func literal which converts a value from in to
+// out with renderCheckedCast, or an empty string if all values of in can be
+// converted to out. It is passed to the helpers of pointer and null fields.
+func renderCheckedFunc(in, out types.Type, name string) string {
+	cast := renderCheckedCast("v", in, out, name)
+	if cast == "" {
+		return ""
+	}
+	p := currentPrinter
+	return "func(v " + p.TypeString(in) + ") (" + p.TypeString(out) + ", error) { return " + cast + " }"
+}
+
+// checkedHelper returns the func which converts between the numeric types, or
+// an empty string if all values of in can be converted to out.
+func checkedHelper(in, out *types.Basic) string {
+	inInfo, outInfo := in.Info(), out.Info()
+	switch {
+	case inInfo&types.IsInteger > 0 && outInfo&types.IsInteger > 0:
+		inBits, outBits := intBits(in.Kind(), false), intBits(out.Kind(), true)
+		inSigned, outSigned := inInfo&types.IsUnsigned == 0, outInfo&types.IsUnsigned == 0
+		if inSigned == outSigned && inBits <= outBits || !inSigned && outSigned && inBits < outBits {
+			return ""
+		}
+		return "CheckedInt"
+
+	case inInfo&types.IsFloat > 0 && outInfo&types.IsInteger > 0:
+		return "CheckedFloatToInt"
+
+	case inInfo&types.IsFloat > 0 && outInfo&types.IsFloat > 0:
+		if in.Kind() == types.Float64 && out.Kind() == types.Float32 {
+			return "CheckedFloat"
+		}
+	}
+	return ""
+}
+
+// intBits returns the size of an integer kind. The size of int, uint and
+// uintptr depends on the platform, so it is the largest one for the input and
+// the smallest one for the output.
+func intBits(kind types.BasicKind, isOutput bool) int {
+	switch kind {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32:
+		return 32
+	case types.Int, types.Uint, types.Uintptr:
+		if isOutput {
+			return 32
+		}
+	}
+	return 64
+}
@@ -0,0 +1,31 @@
+package plugin
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckedHelper(t *testing.T) {
+	for _, tt := range []struct {
+		in, out types.BasicKind
+		helper  string
+	}{
+		{types.Int32, types.Int64, ""},
+		{types.Uint32, types.Int64, ""},
+		{types.Int64, types.Int32, "CheckedInt"},
+		{types.Int, types.Int32, "CheckedInt"},
+		{types.Int32, types.Int, ""},
+		{types.Int64, types.Int, "CheckedInt"},
+		{types.Uint32, types.Int32, "CheckedInt"},
+		{types.Int8, types.Uint64, "CheckedInt"},
+		{types.Float64, types.Int64, "CheckedFloatToInt"},
+		{types.Float64, types.Float32, "CheckedFloat"},
+		{types.Float32, types.Float64, ""},
+		{types.Int64, types.Float64, ""},
+	} {
+		in, out := types.Typ[tt.in], types.Typ[tt.out]
+		assert.Equal(t, tt.helper, checkedHelper(in, out), "%v -> %v", in, out)
+	}
+}
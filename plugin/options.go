@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/olvrng/ggen"
+)
+
+// pkgOptions are the options of a generating package. They follow the
+// packages in the +gen:convert directive, separated by a semicolon:
+//
+//	// +gen:convert: example.com/api -> example.com/model; strict, match=fold, time
+type pkgOptions struct {
+	// strict fails the generation when a field is not converted, because it
+	// has no counterpart or the types do not match, unless it is ignored with
+	// +convert:ignore.
+	strict bool
+
+	// match is the strategy for matching the fields of the structs, which can
+	// be overridden by the +convert:match directive of a type.
+	match matchStrategy
+
+	// enum is the policy for converting the values of an enum which have no
+	// counterpart, which can be overridden by the +convert:enum directive of
+	// the output enum type.
+	enum enumPolicy
+
+	// time enables the conversions between time.Time, *time.Time,
+	// time.Duration, int64 and string fields, with the unit of the int64
+	// fields.
+	time timeUnit
+
+	// nil is the policy for converting a nil pointer to a value of a basic
+	// type, which can be overridden by the +convert:nil directive of a field.
+	nil nilPolicy
+
+	// checked enables the checked conversions of the numeric fields, which
+	// return an error when a value is out of the range of the output type.
+	checked bool
+
+	// strconv enables the conversions between the string fields and the
+	// numeric or bool fields.
+	strconv bool
+}
+
+// currentOptions are the options of the package being generated.
+var currentOptions pkgOptions
+
+// splitOptions splits the arg of the +gen:convert directive into the packages
+// and the options.
+func splitOptions(arg string) (pkgs, opts string) {
+	if idx := strings.Index(arg, ";"); idx >= 0 {
+		return strings.TrimSpace(arg[:idx]), strings.TrimSpace(arg[idx+1:])
+	}
+	return arg, ""
+}
+
+func parsePkgOptions(input string, opts *pkgOptions) error {
+	if input == "" {
+		return nil
+	}
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		key, value := item, ""
+		if idx := strings.Index(item, "="); idx >= 0 {
+			key, value = item[:idx], item[idx+1:]
+		}
+		switch key {
+		case "strict":
+			opts.strict = true
+		case "checked":
+			opts.checked = true
+		case "strconv":
+			opts.strconv = true
+		case "match":
+			match, err := parseMatchStrategy(value)
+			if err != nil {
+				return err
+			}
+			opts.match = match
+		case "enum":
+			policy, err := parseEnumPolicy(value)
+			if err != nil {
+				return err
+			}
+			opts.enum = policy
+		case "time":
+			unit, err := parseTimeUnit(value)
+			if err != nil {
+				return err
+			}
+			opts.time = unit
+		case "nil":
+			policy, err := parseNilPolicy(value)
+			if err != nil {
+				return err
+			}
+			opts.nil = policy
+		default:
+			return ggen.Errorf(nil, "invalid option (%v)", item)
+		}
+	}
+	return nil
+}
@@ -54,6 +54,7 @@ func (p *Convert) Filter(ng ggen.FilterEngine) error {
 
 func (p *Convert) Generate(ng ggen.Engine) error {
 	// currentInfo.Init(ng)
+	currentEngine = ng
 
 	var generatingPackages []*generatingPackage
 	pkgs := ng.GeneratingPackages()
@@ -154,17 +155,27 @@ func (p *Convert) Generate(ng ggen.Engine) error {
 
 	for _, gpkg := range generatingPackages {
 		currentPrinter = gpkg.gpkg.GetPrinter()
+		currentOptions = gpkg.opts
+		strictErrors = nil
+		enumConvs = nil
 		generateComments(currentPrinter, gpkg.customConvs, gpkg.ignoredFuncs)
 		_, err := generateConverts(currentPrinter, gpkg.objMap, gpkg.objList)
 		if err != nil {
 			return err
 		}
+		if err = generateEnumConverts(currentPrinter); err != nil {
+			return err
+		}
+		if err = checkStrict(gpkg.gpkg.PkgPath); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 type generatingPackage struct {
 	gpkg    *ggen.GeneratingPackage
+	opts    pkgOptions
 	objList []objNameDecl
 	objMap  map[objNameDecl]*objMapDecl
 	steps   []*generatingPackageStep
@@ -187,6 +198,8 @@ type objGen struct {
 	mode    string
 	obj     types.Object
 	opts    options
+	names   fieldNames
+	match   matchStrategy
 	convPkg *packages.Package
 }
 
@@ -205,10 +218,20 @@ type options struct {
 }
 
 type fieldConvert struct {
-	Arg *types.Var
-	Out *types.Var
+	Arg    *types.Var
+	Out    *types.Var
+	OutObj types.Object
+
+	// ArgPath are the fields of the nested structs of arg which contain Arg,
+	// when Arg is flattened into Out.
+	ArgPath []*types.Var
+
+	// OutPath are the fields of the nested structs of out which contain Out,
+	// when Arg is unflattened into Out. OutObj is still the outer struct.
+	OutPath []*types.Var
 
 	IsIdentifier bool
+	IsIgnored    bool
 }
 
 type pkgPairDecl struct {
@@ -242,7 +265,7 @@ func parseDirectives(ds []ggen.Directive) (apiPkgs, toPkgs []string, _ error) {
 		if d.Cmd != Command {
 			continue
 		}
-		apiPkgPaths, toPkgPaths, err := parseConvertDirective(d)
+		apiPkgPaths, toPkgPaths, _, err := parseConvertDirective(d)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -261,10 +284,13 @@ func preparePackage(ng ggen.Engine, gpkg *ggen.GeneratingPackage) (*generatingPa
 		if d.Cmd != Command {
 			continue
 		}
-		apiPkgPaths, toPkgPaths, err := parseConvertDirective(d)
+		apiPkgPaths, toPkgPaths, opts, err := parseConvertDirective(d)
 		if err != nil {
 			return nil, err
 		}
+		if err = parsePkgOptions(opts, &result.opts); err != nil {
+			return nil, ggen.Errorf(err, "convert package %v: %v", gpkg.PkgPath, err)
+		}
 		step, err := generatePackageStep(ng, gpkg, gpkg.GetPrinter(), result.objMap, apiPkgPaths, toPkgPaths)
 		if err != nil {
 			return nil, err
@@ -332,6 +358,14 @@ func generatePackageStep(ng ggen.Engine, gpkg *ggen.GeneratingPackage, p ggen.Pr
 			if s := validateStruct(obj); s == nil {
 				continue
 			}
+			names, err := parseFieldNames(ng, obj)
+			if err != nil {
+				return nil, err
+			}
+			match, err := parseTypeMatch(obj, directives)
+			if err != nil {
+				return nil, err
+			}
 
 			flagConvert := false
 			for _, directive := range directives {
@@ -357,6 +391,8 @@ func generatePackageStep(ng ggen.Engine, gpkg *ggen.GeneratingPackage, p ggen.Pr
 					mode:    mode,
 					obj:     obj,
 					opts:    opts,
+					names:   names,
+					match:   match,
 					convPkg: gpkg.Package,
 				})
 			}
@@ -375,6 +411,8 @@ func generatePackageStep(ng ggen.Engine, gpkg *ggen.GeneratingPackage, p ggen.Pr
 					mode:    mode,
 					obj:     obj,
 					opts:    options{},
+					names:   names,
+					match:   match,
 					convPkg: gpkg.Package,
 				})
 			}
@@ -403,6 +441,18 @@ func validateConvertFunc(fn *types.Func) (mode int, arg, out *types.Var, err err
 			out = params.At(1)
 			return 3, arg, out, nil
 		}
+		if validateErrorType(results.At(0).Type()) {
+			arg = params.At(0)
+			out = params.At(1)
+			return 4, arg, out, nil
+		}
+
+	case params.Len() == 1 && results.Len() == 2:
+		if validateErrorType(results.At(1).Type()) {
+			arg = params.At(0)
+			out = results.At(0)
+			return 5, arg, out, nil
+		}
 	}
 
 	return 0, nil, nil, nil
@@ -417,6 +467,10 @@ func validatePtrTypeEquality(t0, t1 types.Type) bool {
 	return ptr0.Elem() == ptr1.Elem()
 }
 
+func validateErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
 func validateEquality(pkgs1, pkgs2 []string) (bool, error) {
 	if len(pkgs1) != len(pkgs2) {
 		return false, nil
@@ -446,17 +500,17 @@ func validateStruct(obj types.Object) *types.Struct {
 	return st
 }
 
-func parseConvertDirective(directive ggen.Directive) (apiPkgs, toPkgs []string, err error) {
-
-	if !strings.Contains(directive.Arg, "->") {
-		pkgs := strings.Split(directive.Arg, ",")
+func parseConvertDirective(directive ggen.Directive) (apiPkgs, toPkgs []string, opts string, err error) {
+	arg, opts := splitOptions(directive.Arg)
+	if !strings.Contains(arg, "->") {
+		pkgs := strings.Split(arg, ",")
 		for i := range pkgs {
 			pkgs[i] = strings.TrimSpace(pkgs[i])
 		}
-		return pkgs, pkgs, nil
+		return pkgs, pkgs, opts, nil
 	}
 
-	parts := strings.Split(directive.Arg, "->")
+	parts := strings.Split(arg, "->")
 	if len(parts) != 2 {
 		err = ggen.Errorf(nil, "invalid directive (must in format pkg1 -> pkg2)")
 		return
@@ -479,7 +533,7 @@ func parseConvertDirective(directive ggen.Directive) (apiPkgs, toPkgs []string,
 			return
 		}
 	}
-	return apiPkgs, toPkgs, nil
+	return apiPkgs, toPkgs, opts, nil
 }
 
 var reName = regexp.MustCompile(`[A-Z][A-z0-9_]*`)
@@ -703,11 +757,11 @@ func generateConverts(
 			var err2 error
 			switch g.mode {
 			case ModeType:
-				err2 = generateConvertType(p, g.obj, m.src)
+				err2 = generateConvertType(p, g.obj, m.src, g.names, g.getMatch())
 			case ModeCreate:
-				err2 = generateCreate(p, g.obj, m.src)
+				err2 = generateCreate(p, g.obj, m.src, g.names, g.getMatch())
 			case ModeUpdate:
-				err2 = generateUpdate(p, g.obj, m.src, g.opts)
+				err2 = generateUpdate(p, g.obj, m.src, g.opts, g.names, g.getMatch())
 			default:
 				panic("unexpected")
 			}
@@ -721,31 +775,39 @@ func generateConverts(
 	return count, nil
 }
 
-func generateConvertType(p ggen.Printer, src, dst types.Object) error {
-	if err := generateConvertTypeImpl(p, src, dst); err != nil {
+// generateConvertType generates the conversions in both directions between
+// src, which is the annotated struct, and dst. The names are the renamed
+// fields of src.
+func generateConvertType(p ggen.Printer, src, dst types.Object, names fieldNames, match matchStrategy) error {
+	if err := validateFieldNames(names, src, dst); err != nil {
 		return err
 	}
-	return generateConvertTypeImpl(p, dst, src)
+	if err := generateConvertTypeImpl(p, src, dst, names, nil, match); err != nil {
+		return err
+	}
+	return generateConvertTypeImpl(p, dst, src, nil, names, match)
 }
 
-func generateConvertTypeImpl(p ggen.Printer, in types.Object, out types.Object) error {
-	inSt := validateStruct(in)
-	outSt := validateStruct(out)
-	fields := make([]fieldConvert, 0, outSt.NumFields())
+func generateConvertTypeImpl(p ggen.Printer, in types.Object, out types.Object, inNames, outNames fieldNames, match matchStrategy) error {
+	matched, err := matchFields(out, in, outNames, inNames, match)
+	if err != nil {
+		return err
+	}
+	fields := make([]fieldConvert, 0, len(matched))
 	embeddedArg, embeddedOut := validateEmbedded(in, out)
-	for i, n := 0, outSt.NumFields(); i < n; i++ {
-		outField := outSt.Field(i)
-		inField := matchField(outField, inSt)
-		if inField != nil || outField != embeddedOut {
-			fields = append(fields, fieldConvert{
-				Arg: inField,
-				Out: outField,
-			})
+	for _, field := range matched {
+		if field.Arg != nil || field.Out != embeddedOut {
+			fields = append(fields, field)
 		}
 	}
 	if embeddedArg != nil {
 		fields = nil
 	}
+	for _, field := range fields {
+		if field.Arg == nil {
+			reportField(field.Out, field.name(), "no matching field in %v", in.Name())
+		}
+	}
 	vars := map[string]interface{}{
 		"Fields":      fields,
 		"EmbeddedArg": embeddedArg,
@@ -756,18 +818,15 @@ func generateConvertTypeImpl(p ggen.Printer, in types.Object, out types.Object)
 	return tplConvertType.Execute(p, vars)
 }
 
-func generateCreate(p ggen.Printer, arg types.Object, out types.Object) error {
-	outSt := validateStruct(out)
-	argSt := validateStruct(arg)
-	fields := make([]fieldConvert, 0, outSt.NumFields())
-	for i, n := 0, outSt.NumFields(); i < n; i++ {
-		outField := outSt.Field(i)
-		argField := matchField(outField, argSt)
-		fields = append(fields, fieldConvert{
-			Arg: argField,
-			Out: outField,
-		})
+func generateCreate(p ggen.Printer, arg types.Object, out types.Object, names fieldNames, match matchStrategy) error {
+	if err := validateFieldNames(names, arg, out); err != nil {
+		return err
+	}
+	fields, err := matchFields(out, arg, nil, names, match)
+	if err != nil {
+		return err
 	}
+	reportUnusedFields(arg, out, fields)
 	vars := map[string]interface{}{
 		"Fields": fields,
 	}
@@ -776,25 +835,22 @@ func generateCreate(p ggen.Printer, arg types.Object, out types.Object) error {
 	return tplCreate.Execute(p, vars)
 }
 
-func generateUpdate(p ggen.Printer, arg types.Object, out types.Object, opts options) error {
-	outSt := validateStruct(out)
-	argSt := validateStruct(arg)
-	fields := make([]fieldConvert, 0, outSt.NumFields())
+func generateUpdate(p ggen.Printer, arg types.Object, out types.Object, opts options, names fieldNames, match matchStrategy) error {
+	if err := validateFieldNames(names, arg, out); err != nil {
+		return err
+	}
+	fields, err := matchFields(out, arg, nil, names, match)
+	if err != nil {
+		return err
+	}
 	identCount := 0
-	for i, n := 0, outSt.NumFields(); i < n; i++ {
-		outField := outSt.Field(i)
-		argField := matchField(outField, argSt)
-		isIdentifier := contains(opts.identifiers, outField.Name())
-		if isIdentifier {
+	for i := range fields {
+		if !fields[i].IsNested() && contains(opts.identifiers, fields[i].Out.Name()) {
+			fields[i].IsIdentifier = true
 			identCount++
 		}
-		fields = append(fields, fieldConvert{
-			Arg: argField,
-			Out: outField,
-
-			IsIdentifier: isIdentifier,
-		})
 	}
+	reportUnusedFields(arg, out, fields)
 	if identCount != len(opts.identifiers) {
 		return fmt.Errorf("update %v: identifier not found (%v)", arg.Name(), strings.Join(opts.identifiers, ","))
 	}
@@ -806,6 +862,24 @@ func generateUpdate(p ggen.Printer, arg types.Object, out types.Object, opts opt
 	return tplUpdate.Execute(p, vars)
 }
 
+// reportUnusedFields reports the fields of arg which are not converted to any
+// field of out. In create and update modes, the fields of out which are not in
+// arg are left unchanged.
+func reportUnusedFields(arg, out types.Object, fields []fieldConvert) {
+	used := make(map[*types.Var]bool)
+	for _, field := range fields {
+		if field.Arg != nil && !field.IsIgnored {
+			used[field.argField()] = true
+		}
+	}
+	argSt := validateStruct(arg)
+	for i, n := 0, argSt.NumFields(); i < n; i++ {
+		if field := argSt.Field(i); !used[field] {
+			reportField(field, arg.Name()+"."+field.Name(), "no matching field in %v", out.Name())
+		}
+	}
+}
+
 func includeBaseConversion(p ggen.Printer, vars map[string]interface{}, mode string, arg types.Object, out types.Object) {
 	outType := p.TypeString(out.Type())
 	argType := p.TypeString(arg.Type())
@@ -896,6 +970,14 @@ func validateCompatible(arg, out types.Object) bool {
 			}
 		}
 	}
+	{
+		_, ok0 := arg.Type().(*types.Map)
+		_, ok1 := out.Type().(*types.Map)
+		if ok0 && ok1 && types.Identical(arg.Type(), out.Type()) {
+			ll.V(1).Printf("map[Key]Type %v %v", arg, out)
+			return true
+		}
+	}
 	return false
 }
 
@@ -989,16 +1071,6 @@ func contains(ss []string, item string) bool {
 	return false
 }
 
-func matchField(baseField *types.Var, st *types.Struct) *types.Var {
-	for i, n := 0, st.NumFields(); i < n; i++ {
-		field := st.Field(i)
-		if field.Name() == baseField.Name() {
-			return field
-		}
-	}
-	return nil
-}
-
 func w(w io.Writer, format string, args ...interface{}) {
 	_, err := fmt.Fprintf(w, format, args...)
 	if err != nil {
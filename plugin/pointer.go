@@ -0,0 +1,164 @@
+package plugin
+
+import (
+	"go/types"
+	"strconv"
+
+	"github.com/olvrng/ggen"
+)
+
+const DirectiveNil = "convert:nil"
+
+// nilPolicy decides how a nil pointer is converted to a value, when converting
+// a pointer field to a value field of basic types. It is chosen with the nil
+// option of the +gen:convert directive, or with the +convert:nil directive of
+// one of the fields:
+//
+//	// +gen:convert: example.com/api -> example.com/model; nil=keep
+//
+//	type User struct {
+//	    Name *string // +convert:nil=error
+//	}
+type nilPolicy string
+
+const (
+	// NilZero converts a nil pointer to the zero value. It is the default.
+	NilZero nilPolicy = "zero"
+
+	// NilKeep leaves the output field unchanged.
+	NilKeep nilPolicy = "keep"
+
+	// NilError returns an error with the name of the field.
+	NilError nilPolicy = "error"
+)
+
+func parseNilPolicy(input string) (nilPolicy, error) {
+	switch s := nilPolicy(input); s {
+	case NilZero, NilKeep, NilError:
+		return s, nil
+	default:
+		return "", ggen.Errorf(nil, "invalid nil policy (%v)", input)
+	}
+}
+
+// getNilPolicy returns the policy in the +convert:nil directive of the fields,
+// or the one of the package. An invalid directive is reported and ignored.
+func getNilPolicy(in, out *types.Var) nilPolicy {
+	for _, field := range []*types.Var{out, in} {
+		for _, d := range getFieldDirectives(currentEngine, field) {
+			if d.Cmd != DirectiveNil {
+				continue
+			}
+			policy, err := parseNilPolicy(d.Arg)
+			if err != nil {
+				ll.V(0).Printf("warning: %v: %v", objPosition(field), err)
+				continue
+			}
+			return policy
+		}
+	}
+	if currentOptions.nil != "" {
+		return currentOptions.nil
+	}
+	return NilZero
+}
+
+// pointerElem returns the element type of a pointer type, or the type itself.
+func pointerElem(typ types.Type) (_ types.Type, isPointer bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return ptr.Elem(), true
+	}
+	return typ, false
+}
+
+// renderPointerConversion renders the conversion between basic fields where at
+// least one of them is a pointer, such as *string to string or int64 to
+// *int32. The target is the output field, which is kept with the keep policy.
+func renderPointerConversion(in, out *types.Var, prefix, name, target string) string {
+	inElem, inPtr := pointerElem(in.Type())
+	outElem, outPtr := pointerElem(out.Type())
+	if !inPtr && !outPtr || !canCast(checkBasicType(inElem), checkBasicType(outElem)) {
+		return ""
+	}
+	value := prefix + "." + in.Name()
+	convertPointer := func(value string) string {
+		return "conversion.ConvertPointer(" + value + ", func(v " + currentPrinter.TypeString(inElem) + ") " +
+			currentPrinter.TypeString(outElem) + " { return " + renderCast("v", inElem, outElem) + " })"
+	}
+	if isChecked(in, out) {
+		if result := renderCheckedPointerConversion(in, out, value, name, target); result != "" {
+			return result
+		}
+	}
+	lastComment = "// pointer conversion"
+	lastError = false
+	switch {
+	case inPtr && outPtr:
+		lastComment = "// simple conversion"
+		return convertPointer(value)
+	case !inPtr:
+		return "conversion.Pointer(" + renderCast(value, inElem, outElem) + ")"
+	}
+
+	switch getNilPolicy(in, out) {
+	case NilKeep:
+		if !types.Identical(inElem, outElem) {
+			value = convertPointer(value)
+		}
+		return "conversion.PointerValueOr(" + value + ", " + target + ")"
+	case NilError:
+		if !types.Identical(inElem, outElem) {
+			value = convertPointer(value)
+		}
+		lastError = true
+		return "conversion.RequirePointerValue(" + value + ", " + strconv.Quote(name) + ")"
+	default:
+		return renderCast("conversion.PointerValue("+value+")", inElem, outElem)
+	}
+}
+
+// renderCheckedPointerConversion renders the conversion of renderPointerConversion
+// with a range check, or an empty string if the conversion does not need one.
+func renderCheckedPointerConversion(in, out *types.Var, value, name, target string) string {
+	inElem, inPtr := pointerElem(in.Type())
+	outElem, outPtr := pointerElem(out.Type())
+	check := renderCheckedFunc(inElem, outElem, name)
+	if check == "" {
+		return ""
+	}
+	lastComment = "// checked conversion"
+	lastError = true
+	switch {
+	case inPtr && outPtr:
+		return "conversion.ConvertPointerErr(" + value + ", " + check + ")"
+	case !inPtr:
+		return "conversion.ConvertPointerErr(&" + value + ", " + check + ")"
+	}
+	switch getNilPolicy(in, out) {
+	case NilKeep:
+		return "conversion.ConvertPointerValueOr(" + value + ", " + target + ", " + check + ")"
+	case NilError:
+		return "conversion.ConvertRequiredPointer(" + value + ", " + strconv.Quote(name) + ", " + check + ")"
+	default:
+		return renderCheckedCast("conversion.PointerValue("+value+")", inElem, outElem, name)
+	}
+}
+
+// canCast reports whether a value of basic type in can be converted to out
+// with a type conversion.
+func canCast(in, out *types.Basic) bool {
+	if in == nil || out == nil {
+		return false
+	}
+	return in.Kind() == out.Kind() ||
+		in.Info()&types.IsNumeric > 0 && out.Info()&types.IsNumeric > 0
+}
+
+// renderCast renders the type conversion of expr from in to out, or expr if
+// the types are identical.
+func renderCast(expr string, in, out types.Type) string {
+	if types.Identical(in, out) {
+		return expr
+	}
+	return currentPrinter.TypeString(out) + "(" + expr + ")"
+}
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"go/types"
+	"strconv"
+)
+
+// DirectiveStrconv enables the conversions between the string field and the
+// numeric or bool field, as the strconv option of the +gen:convert directive
+// does for the package:
+//
+//	// +gen:convert: example.com/api -> example.com/model; strconv
+//
+//	type User struct {
+//	    Age string // +convert:strconv
+//	}
+const DirectiveStrconv = "convert:strconv"
+
+// isStrconv reports whether the fields are converted with strconv.
+func isStrconv(in, out *types.Var) bool {
+	return currentOptions.strconv || hasFieldDirective(in, out, DirectiveStrconv)
+}
+
+// renderStrconvConversion renders the conversion between a string field and an
+// integer, float or bool field. Formatting calls the strconv funcs, and parsing
+// returns an error prefixed with the name of the field. The types of package
+// time, such as time.Duration, are left to the time conversion.
+func renderStrconvConversion(in, out *types.Var, prefix, name string) string {
+	inBasic, outBasic := checkBasicType(in.Type()), checkBasicType(out.Type())
+	if inBasic == nil || outBasic == nil {
+		return ""
+	}
+	inNamed, _ := in.Type().(*types.Named)
+	outNamed, _ := out.Type().(*types.Named)
+	if isTimePackage(inNamed) || isTimePackage(outNamed) {
+		return ""
+	}
+	inString, outString := inBasic.Info()&types.IsString > 0, outBasic.Info()&types.IsString > 0
+	if inString == outString {
+		return ""
+	}
+	if !isStrconv(in, out) {
+		return ""
+	}
+	value := prefix + "." + in.Name()
+	if inString {
+		result := renderParse(renderCast(value, in.Type(), types.Typ[types.String]), outBasic, out.Type(), name)
+		if result == "" {
+			return ""
+		}
+		lastComment = "// strconv conversion"
+		lastError = true
+		return result
+	}
+	result := renderFormat(value, inBasic, in.Type())
+	if result == "" {
+		return ""
+	}
+	currentPrinter.Import("strconv", "strconv")
+	lastComment = "// strconv conversion"
+	lastError = false
+	return renderCast(result, types.Typ[types.String], out.Type())
+}
+
+func renderFormat(value string, basic *types.Basic, typ types.Type) string {
+	info := basic.Info()
+	switch {
+	case info&types.IsUnsigned > 0:
+		return "strconv.FormatUint(" + renderCast(value, typ, types.Typ[types.Uint64]) + ", 10)"
+	case info&types.IsInteger > 0:
+		return "strconv.FormatInt(" + renderCast(value, typ, types.Typ[types.Int64]) + ", 10)"
+	case info&types.IsFloat > 0:
+		return "strconv.FormatFloat(" + renderCast(value, typ, types.Typ[types.Float64]) +
+			", 'g', -1, " + strconv.Itoa(basicBits(basic.Kind())) + ")"
+	case info&types.IsBoolean > 0:
+		return "strconv.FormatBool(" + renderCast(value, typ, types.Typ[types.Bool]) + ")"
+	}
+	return ""
+}
+
+func renderParse(value string, basic *types.Basic, typ types.Type, name string) string {
+	info := basic.Info()
+	outType := currentPrinter.TypeString(typ)
+	bits := strconv.Itoa(basicBits(basic.Kind()))
+	switch {
+	case info&types.IsUnsigned > 0:
+		return "conversion.ParseUint[" + outType + "](" + value + ", " + bits + ", " + strconv.Quote(name) + ")"
+	case info&types.IsInteger > 0:
+		return "conversion.ParseInt[" + outType + "](" + value + ", " + bits + ", " + strconv.Quote(name) + ")"
+	case info&types.IsFloat > 0:
+		return "conversion.ParseFloat[" + outType + "](" + value + ", " + bits + ", " + strconv.Quote(name) + ")"
+	case info&types.IsBoolean > 0:
+		return "conversion.ParseBool[" + outType + "](" + value + ", " + strconv.Quote(name) + ")"
+	}
+	return ""
+}
+
+// basicBits returns the bit size of a numeric kind for strconv, which is 0 for
+// the kinds whose size depends on the platform.
+func basicBits(kind types.BasicKind) int {
+	switch kind {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	}
+	return 0
+}
@@ -12,17 +12,21 @@ import (
 var tplRegister, tplConvertType, tplUpdate, tplCreate *template.Template
 
 // var currentInfo *parse.Info
+var currentEngine ggen.Engine
 var currentPrinter ggen.Printer
 var convPairs map[convPair]*conversionFunc
 
 func init() {
 	funcMap := map[string]interface{}{
-		"embeddedConvert": renderEmbeddedConvert,
-		"fieldName":       renderFieldName,
-		"fieldValue":      renderFieldValue,
-		"fieldApply":      renderFieldApply,
-		"lastComment":     renderLastComment,
-		"plural":          plural,
+		"embeddedConvert":  renderEmbeddedConvert,
+		"fieldName":        renderFieldName,
+		"fieldValue":       renderFieldValue,
+		"fieldApply":       renderFieldApply,
+		"fieldNested":      renderNestedField,
+		"fieldNestedApply": renderNestedApply,
+		"lastComment":      renderLastComment,
+		"lastError":        renderLastError,
+		"plural":           plural,
 	}
 	parse := func(name, text string) *template.Template {
 		return template.Must(template.New(name).Funcs(funcMap).Parse(text))
@@ -36,65 +40,196 @@ func init() {
 
 var lastComment string
 
+// lastError reports whether the last rendered value returns an additional
+// error, which must be checked by the caller.
+var lastError bool
+
 func renderLastComment() string {
 	return lastComment
 }
 
+func renderLastError() bool {
+	return lastError
+}
+
 func renderFieldName(field fieldConvert) string {
 	return field.Out.Name()
 }
 
 func renderFieldValue(prefix string, field fieldConvert) string {
 	in, out := field.Arg, field.Out
+	lastError = false
+	if field.IsIgnored {
+		lastComment = "// ignored"
+		return field.target()
+	}
 	if in == nil {
 		lastComment = "// no change"
-		return "out." + out.Name()
+		return field.target()
 		// return renderZero(out.Type())
 	}
 	if validateCompatible(in, out) {
 		lastComment = "// simple assign"
 		return prefix + "." + in.Name()
 	}
-	if result := renderCustomConversion(in, out, prefix); result != "" {
-		return result
-	}
-	if result := renderSimpleConversion(in, out, prefix); result != "" {
-		return result
-	}
-	lastComment = "// types do not match"
-	return "out." + out.Name()
-	// return renderZero(out.Type())
+	return renderConversion(prefix, field)
 }
 
 func renderFieldApply(prefix string, field fieldConvert) string {
 	arg, out := field.Arg, field.Out
+	lastError = false
 	if field.IsIdentifier {
 		lastComment = "// identifier"
-		return "out." + out.Name()
+		return field.target()
+	}
+	if field.IsIgnored {
+		lastComment = "// ignored"
+		return field.target()
 	}
 	if arg == nil {
 		lastComment = "// no change"
-		return "out." + out.Name()
+		return field.target()
 	}
 	if validateCompatible(arg, out) {
 		lastComment = "// simple assign"
-		return "arg." + out.Name()
+		return prefix + "." + arg.Name()
 	}
 	// render NullString, NullInt, ...Apply()
 	if argType, ok := arg.Type().(*types.Named); ok {
 		if checkApplicable(argType) {
 			lastComment = "// apply change"
-			return prefix + "." + arg.Name() + ".Apply(out." + out.Name() + ")"
+			return prefix + "." + arg.Name() + ".Apply(" + field.target() + ")"
 		}
 	}
-	if result := renderCustomConversion(arg, out, prefix); result != "" {
+	return renderConversion(prefix, field)
+}
+
+// renderConversion renders the conversion between two fields of different
+// types, with the first conversion which supports them. It is shared by
+// renderFieldValue and renderFieldApply, so that both modes convert the
+// fields in the same order.
+func renderConversion(prefix string, field fieldConvert) string {
+	in, out, name := field.Arg, field.Out, field.name()
+	if result := renderTimeConversion(in, out, prefix, name); result != "" {
+		return result
+	}
+	if result := renderCustomConversion(in, out, prefix); result != "" {
 		return result
 	}
-	if result := renderSimpleConversion(arg, out, prefix); result != "" {
+	if result := renderEnumConversion(in, out, prefix); result != "" {
 		return result
 	}
+	if result := renderMapConversion(in, out, prefix); result != "" {
+		return result
+	}
+	if result := renderValueConversion(in, out, prefix); result != "" {
+		return result
+	}
+	if result := renderNullConversion(in, out, prefix, name); result != "" {
+		return result
+	}
+	if result := renderTextConversion(in, out, prefix, name); result != "" {
+		return result
+	}
+	if result := renderStrconvConversion(in, out, prefix, name); result != "" {
+		return result
+	}
+	if result := renderCheckedConversion(in, out, prefix, name); result != "" {
+		return result
+	}
+	if result := renderSimpleConversion(in, out, prefix); result != "" {
+		return result
+	}
+	if result := renderPointerConversion(in, out, prefix, name, field.target()); result != "" {
+		return result
+	}
+	reportField(out, name, "types do not match (%v -> %v)",
+		currentPrinter.TypeString(in.Type()), currentPrinter.TypeString(out.Type()))
 	lastComment = "// types do not match"
-	return "out." + out.Name()
+	return field.target()
+	// return renderZero(out.Type())
+}
+
+// renderNestedField renders the statements which convert a flattened or an
+// unflattened field. Reading from a nil pointer in the path of arg results in
+// the zero value, and the nil pointers in the path of out are only allocated
+// when the value is not zero.
+func renderNestedField(prefix string, field fieldConvert) string {
+	return renderNested(prefix, field, renderFieldValue)
+}
+
+// renderNestedApply renders a flattened or an unflattened field in update
+// mode, with the rules of renderFieldApply.
+func renderNestedApply(prefix string, field fieldConvert) string {
+	return renderNested(prefix, field, renderFieldApply)
+}
+
+func renderNested(prefix string, field fieldConvert, render func(string, fieldConvert) string) string {
+	argPrefix, argGuards := prefix, []string(nil)
+	for _, v := range field.ArgPath {
+		argPrefix += "." + v.Name()
+		if _, ok := v.Type().(*types.Pointer); ok {
+			argGuards = append(argGuards, argPrefix+" != nil")
+		}
+	}
+	target, outGuards, allocs := "out", []string(nil), []string(nil)
+	for _, v := range field.OutPath {
+		target += "." + v.Name()
+		if ptr, ok := v.Type().(*types.Pointer); ok {
+			outGuards = append(outGuards, target+" != nil")
+			allocs = append(allocs, fmt.Sprintf("if %v == nil {\n%v = new(%v)\n}",
+				target, target, currentPrinter.TypeString(ptr.Elem())))
+		}
+	}
+	target += "." + field.Out.Name()
+
+	value := render(argPrefix, field)
+	if lastComment == "// types do not match" {
+		return "// " + target + ": types do not match"
+	}
+	stmt := target + " = " + value + " " + lastComment
+	if lastError {
+		stmt = fmt.Sprintf("if %v, err = %v; err != nil {\nreturn err\n}", target, value)
+	}
+
+	var b strings.Builder
+	if len(argGuards) != 0 {
+		fmt.Fprintf(&b, "if %v {\n", strings.Join(argGuards, " && "))
+	}
+	if len(allocs) != 0 {
+		fmt.Fprintf(&b, "if %v {\n%v\n}\n", renderNonZero(argPrefix+"."+field.Arg.Name(), field.Arg.Type()), strings.Join(allocs, "\n"))
+		fmt.Fprintf(&b, "if %v {\n%v\n}", strings.Join(outGuards, " && "), stmt)
+	} else {
+		b.WriteString(stmt)
+	}
+	if len(argGuards) != 0 {
+		fmt.Fprintf(&b, "\n} else {\n%v = %v\n}", target, renderZero(field.Out.Type()))
+	}
+	return b.String()
+}
+
+// renderNonZero renders the condition which reports whether the expression is
+// not the zero value of its type.
+func renderNonZero(expr string, typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsBoolean > 0:
+			return expr
+		case info&types.IsString > 0:
+			return expr + ` != ""`
+		case info&types.IsNumeric > 0:
+			return expr + " != 0"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Chan, *types.Signature:
+		return expr + " != nil"
+	case *types.Struct:
+		if _, ok := typ.(*types.Named); ok && types.Comparable(typ) {
+			return expr + " != (" + currentPrinter.TypeString(typ) + "{})"
+		}
+	}
+	return "true"
 }
 
 func renderCustomConversion(in, out *types.Var, prefix string) string {
@@ -106,6 +241,7 @@ func renderCustomConversion(in, out *types.Var, prefix string) string {
 				return ""
 			}
 			lastComment = ""
+			lastError = true
 			return renderCustomConversion0(true, argNamed, outNamed, conv, prefix+"."+in.Name())
 		}
 	}
@@ -117,6 +253,7 @@ func renderCustomConversion(in, out *types.Var, prefix string) string {
 				return ""
 			}
 			lastComment = ""
+			lastError = true
 			return renderCustomConversion0(false, argNamed, outNamed, conv, prefix+"."+in.Name())
 		}
 	}
@@ -124,6 +261,15 @@ func renderCustomConversion(in, out *types.Var, prefix string) string {
 }
 
 func renderCustomConversion0(isPlural bool, in, out *types.Named, conv *conversionFunc, inField string) string {
+	if isPlural {
+		return renderConvertFunc(isPlural, in, out, conv) + "(" + inField + ")"
+	}
+	return renderConvertFunc(isPlural, in, out, conv) + "(" + inField + ", nil)"
+}
+
+// renderConvertFunc renders the name of the generated func which converts
+// between in and out, qualified with the package of the converter.
+func renderConvertFunc(isPlural bool, in, out *types.Named, conv *conversionFunc) string {
 	p := currentPrinter
 	inType := p.TypeString(in)
 	outType := p.TypeString(out)
@@ -133,12 +279,7 @@ func renderCustomConversion0(isPlural bool, in, out *types.Named, conv *conversi
 		inStr = plural(inStr)
 		outStr = plural(outStr)
 	}
-	var result string
-	if isPlural {
-		result = "Convert_" + inStr + "_" + outStr + "(" + inField + ")"
-	} else {
-		result = "Convert_" + inStr + "_" + outStr + "(" + inField + ", nil)"
-	}
+	result := "Convert_" + inStr + "_" + outStr
 	if conv.ConverterPkg == nil {
 		panic(fmt.Sprintf(
 			"There is custom conversion function %v.%v to convert between %v.%v and %v.%v, but no generated conversion package between %v and %v. You must create one (+gen:convert: %v->%v) or delete the custom conversion function.",
@@ -156,20 +297,108 @@ func renderCustomConversion0(isPlural bool, in, out *types.Named, conv *conversi
 	return result
 }
 
+// renderMapConversion renders the conversion between two map fields. The
+// elements are converted with the generated or custom conversion between
+// them, and the keys can differ by named basic types. Nil maps stay nil.
+func renderMapConversion(in, out *types.Var, prefix string) string {
+	inMap, ok0 := in.Type().Underlying().(*types.Map)
+	outMap, ok1 := out.Type().Underlying().(*types.Map)
+	if !ok0 || !ok1 {
+		return ""
+	}
+	key := renderMapKey(inMap.Key(), outMap.Key())
+	if key == "" {
+		return ""
+	}
+	inField := prefix + "." + in.Name()
+	if types.Identical(inMap.Elem(), outMap.Elem()) {
+		lastComment = "// convert map keys"
+		return "conversion.ConvertMapKeys(" + inField + ", " + key + ")"
+	}
+
+	helper := "conversion.ConvertMap"
+	inNamed := validatePointerToNamed(inMap.Elem())
+	outNamed := validatePointerToNamed(outMap.Elem())
+	if inNamed == nil && outNamed == nil {
+		helper = "conversion.ConvertMapValue"
+		inNamed, _ = inMap.Elem().(*types.Named)
+		outNamed, _ = outMap.Elem().(*types.Named)
+	}
+	if inNamed == nil || outNamed == nil {
+		return ""
+	}
+	conv := convPairs[convPair{valid: true, Arg: getObjName(inNamed), Out: getObjName(outNamed)}]
+	if conv == nil {
+		return ""
+	}
+	lastComment = ""
+	lastError = true
+	return helper + "(" + inField + ", " + key + ", " + renderConvertFunc(false, inNamed, outNamed, conv) + ")"
+}
+
+// renderValueConversion renders the conversion between two fields of value
+// slices, values, or a pointer and a value, using the generated or custom
+// conversion between their named types. A nil pointer is converted to the
+// zero value (see package conversion).
+func renderValueConversion(in, out *types.Var, prefix string) string {
+	var helper string
+	var inNamed, outNamed *types.Named
+	inSlice, ok0 := in.Type().Underlying().(*types.Slice)
+	outSlice, ok1 := out.Type().Underlying().(*types.Slice)
+	switch {
+	case ok0 && ok1:
+		helper = "conversion.ConvertSliceValue"
+		inNamed, _ = inSlice.Elem().(*types.Named)
+		outNamed, _ = outSlice.Elem().(*types.Named)
+
+	case validatePointerToNamed(in.Type()) != nil:
+		helper = "conversion.ConvertPointerToValue"
+		inNamed = validatePointerToNamed(in.Type())
+		outNamed, _ = out.Type().(*types.Named)
+
+	case validatePointerToNamed(out.Type()) != nil:
+		helper = "conversion.ConvertValueToPointer"
+		inNamed, _ = in.Type().(*types.Named)
+		outNamed = validatePointerToNamed(out.Type())
+
+	default:
+		helper = "conversion.ConvertValue"
+		inNamed, _ = in.Type().(*types.Named)
+		outNamed, _ = out.Type().(*types.Named)
+	}
+	if inNamed == nil || outNamed == nil {
+		return ""
+	}
+	conv := convPairs[convPair{valid: true, Arg: getObjName(inNamed), Out: getObjName(outNamed)}]
+	if conv == nil {
+		return ""
+	}
+	lastComment = ""
+	lastError = true
+	return helper + "(" + prefix + "." + in.Name() + ", " + renderConvertFunc(false, inNamed, outNamed, conv) + ")"
+}
+
+// renderMapKey renders the func which converts the keys of a map, or an empty
+// string if the keys can not be converted.
+func renderMapKey(in, out types.Type) string {
+	p := currentPrinter
+	if types.Identical(in, out) {
+		return "conversion.Identity[" + p.TypeString(in) + "]"
+	}
+	inBasic := checkBasicType(in)
+	outBasic := checkBasicType(out)
+	if inBasic == nil || outBasic == nil || inBasic.Kind() != outBasic.Kind() {
+		return ""
+	}
+	return fmt.Sprintf("func(k %v) %v { return %v(k) }",
+		p.TypeString(in), p.TypeString(out), p.TypeString(out))
+}
+
 func renderSimpleConversion(in, out *types.Var, prefix string) string {
 	// convert basic types
-	inBasic := checkBasicType(in.Type())
-	outBasic := checkBasicType(out.Type())
-	if inBasic != nil && outBasic != nil {
-		outStr := currentPrinter.TypeString(out.Type())
-		if inBasic.Kind() == outBasic.Kind() {
-			lastComment = "// simple conversion"
-			return outStr + "(" + prefix + "." + in.Name() + ")"
-		}
-		if inBasic.Info()&types.IsNumeric > 0 && outBasic.Info()&types.IsNumeric > 0 {
-			lastComment = "// simple conversion"
-			return outStr + "(" + prefix + "." + in.Name() + ")"
-		}
+	if canCast(checkBasicType(in.Type()), checkBasicType(out.Type())) {
+		lastComment = "// simple conversion"
+		return currentPrinter.TypeString(out.Type()) + "(" + prefix + "." + in.Name() + ")"
 	}
 	return ""
 }
@@ -7,15 +7,16 @@ func RegisterConversions(s *conversion.Scheme) {
 
 func registerConversions(s *conversion.Scheme) {
 {{range .Conversions -}}
-    s.Register((*{{.ArgType}})(nil), (*{{.OutType}})(nil), func(arg, out interface{}) error {
-        {{.Actions}}_{{.ArgStr}}_{{.OutStr}}(arg.(*{{.ArgType}}), out.(*{{.OutType}}))
-        return nil
+    conversion.Register(s, func(arg *{{.ArgType}}, out *{{.OutType}}) error {
+        return conversion.CallInto({{.Actions}}_{{.ArgStr}}_{{.OutStr}}, arg, out)
     })
     {{if .Actions|eq "Convert" -}}
-    s.Register(([]*{{.ArgType}})(nil), (*[]*{{.OutType}})(nil), func(arg, out interface{}) error {
-        out0 := {{.Actions}}_{{.ArgStr|plural}}_{{.OutStr|plural}}(arg.([]*{{.ArgType}}))
-        *out.(*[]*{{.OutType}}) = out0
-        return nil
+    conversion.RegisterSlice(s, {{.Actions}}_{{.ArgStr|plural}}_{{.OutStr|plural}})
+    conversion.RegisterValueSlice(s, func(arg *{{.ArgType}}, out *{{.OutType}}) error {
+        return conversion.CallInto({{.Actions}}_{{.ArgStr}}_{{.OutStr}}, arg, out)
+    })
+    conversion.RegisterMap(s, func(arg *{{.ArgType}}, out *{{.OutType}}) error {
+        return conversion.CallInto({{.Actions}}_{{.ArgStr}}_{{.OutStr}}, arg, out)
     })
     {{end -}}
 {{end -}}
@@ -23,66 +24,119 @@ func registerConversions(s *conversion.Scheme) {
 `
 
 const tplConvertCustomText = `
-func {{.Actions}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) *{{.OutType}} {
+func {{.Actions}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) (*{{.OutType}}, error) {
   {{- if .CustomConversionMode|eq 1}}
-    return {{.CustomConversionFuncName}}(arg)
+    return {{.CustomConversionFuncName}}(arg), nil
   {{- else if .CustomConversionMode|eq 2}}
     if arg == nil {
-        return nil
+        return nil, nil
     }
     if out == nil {
         out = &{{.OutType}}{}
     }
   {{.CustomConversionFuncName}}(arg, out)
-    return out
+    return out, nil
   {{- else if .CustomConversionMode|eq 3}}
-    return {{.CustomConversionFuncName}}(arg, out)
+    return {{.CustomConversionFuncName}}(arg, out), nil
+  {{- else if .CustomConversionMode|eq 4}}
+    if arg == nil {
+        return nil, nil
+    }
+    if out == nil {
+        out = &{{.OutType}}{}
+    }
+    if err := {{.CustomConversionFuncName}}(arg, out); err != nil {
+        return nil, err
+    }
+    return out, nil
+  {{- else if .CustomConversionMode|eq 5}}
+    return {{.CustomConversionFuncName}}(arg)
   {{- else}}
     if arg == nil {
-        return nil
+        return nil, nil
     }
     if out == nil {
         out = &{{.OutType}}{}
     }
-  {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg, out)
-    return out
+    if err := {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg, out); err != nil {
+        return nil, err
+    }
+    return out, nil
   {{- end}}
 }
 `
 
 const tplConvertTypeText = tplConvertCustomText + `
-func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) {
+func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) (err error) {
 	{{- .|embeddedConvert -}}
 	{{- range .Fields}}
-		out.{{.|fieldName}} = {{.|fieldValue "arg"}} {{lastComment -}}
-	{{end}}
+		{{- if .IsNested}}
+		{{.|fieldNested "arg"}}
+		{{- else}}
+		{{- $value := .|fieldValue "arg"}}
+		{{- if lastError}}
+		if out.{{.|fieldName}}, err = {{$value}}; err != nil {
+			return err
+		}
+		{{- else}}
+		out.{{.|fieldName}} = {{$value}} {{lastComment -}}
+		{{- end}}
+		{{- end}}
+	{{- end}}
+	return nil
 }
 
-func {{.Actions}}_{{.ArgStr|plural}}_{{.OutStr|plural}}(args []*{{.ArgType}})(outs []*{{.OutType}}) {
+func {{.Actions}}_{{.ArgStr|plural}}_{{.OutStr|plural}}(args []*{{.ArgType}})(outs []*{{.OutType}}, err error) {
   if args == nil {
-    return nil
+    return nil, nil
   }
   tmps := make([]{{.OutType}}, len(args))
   outs = make([]*{{.OutType}}, len(args))
 	for i := range tmps {
-		outs[i] = Convert_{{.ArgStr}}_{{.OutStr}}(args[i], &tmps[i])
+		if outs[i], err = Convert_{{.ArgStr}}_{{.OutStr}}(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
   }
-  return outs
+  return outs, nil
 }
 `
 
 const tplCreateText = tplConvertCustomText + `
-func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) {
+func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) (err error) {
 	{{- range .Fields}}
-		out.{{.|fieldName}} = {{.|fieldValue "arg"}} {{lastComment -}}
-	{{end}}
+		{{- if .IsNested}}
+		{{.|fieldNested "arg"}}
+		{{- else}}
+		{{- $value := .|fieldValue "arg"}}
+		{{- if lastError}}
+		if out.{{.|fieldName}}, err = {{$value}}; err != nil {
+			return err
+		}
+		{{- else}}
+		out.{{.|fieldName}} = {{$value}} {{lastComment -}}
+		{{- end}}
+		{{- end}}
+	{{- end}}
+	return nil
 }
 `
 
 const tplUpdateText = tplConvertCustomText + `
-func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) {
+func {{.action}}_{{.ArgStr}}_{{.OutStr}}(arg *{{.ArgType}}, out *{{.OutType}}) (err error) {
   {{- range .Fields}}
-	out.{{.|fieldName}} = {{.|fieldApply "arg"}} {{lastComment -}}
-  {{end}}
+	{{- if .IsNested}}
+	{{.|fieldNestedApply "arg"}}
+	{{- else}}
+	{{- $value := .|fieldApply "arg"}}
+	{{- if lastError}}
+	if out.{{.|fieldName}}, err = {{$value}}; err != nil {
+		return err
+	}
+	{{- else}}
+	out.{{.|fieldName}} = {{$value}} {{lastComment -}}
+	{{- end}}
+	{{- end}}
+  {{- end}}
+	return nil
 }
 `
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// The interfaces encoding.TextMarshaler, encoding.TextUnmarshaler and
+// fmt.Stringer, which are detected on the types of the fields.
+var (
+	textMarshaler   = newInterface("MarshalText", nil, []types.Type{byteSlice, errorType})
+	textUnmarshaler = newInterface("UnmarshalText", []types.Type{byteSlice}, []types.Type{errorType})
+	stringer        = newInterface("String", nil, []types.Type{types.Typ[types.String]})
+)
+
+var (
+	byteSlice = types.NewSlice(types.Typ[types.Byte])
+	errorType = types.Universe.Lookup("error").Type()
+)
+
+func newInterface(name string, params, results []types.Type) *types.Interface {
+	newTuple := func(typs []types.Type) *types.Tuple {
+		vars := make([]*types.Var, len(typs))
+		for i, typ := range typs {
+			vars[i] = types.NewParam(token.NoPos, nil, "", typ)
+		}
+		return types.NewTuple(vars...)
+	}
+	sig := types.NewSignatureType(nil, nil, nil, newTuple(params), newTuple(results), false)
+	return types.NewInterfaceType([]*types.Func{types.NewFunc(token.NoPos, nil, name, sig)}, nil).Complete()
+}
+
+// implements reports whether the type implements the interface, and whether it
+// needs a pointer to do so, because the methods have pointer receivers.
+func implements(typ types.Type, iface *types.Interface) (ok, needPointer bool) {
+	if types.Implements(typ, iface) {
+		return true, false
+	}
+	if _, isPtr := typ.Underlying().(*types.Pointer); !isPtr && types.Implements(types.NewPointer(typ), iface) {
+		return true, true
+	}
+	return false, false
+}
+
+// renderTextConversion renders the conversion between a named type and a
+// string field, with the MarshalText and UnmarshalText methods of the type,
+// or with its String method when converting to a string. The types which can
+// be cast, such as a named string type with a String method, are left to the
+// simple conversion, and the types of package time to the time conversion.
+func renderTextConversion(in, out *types.Var, prefix, name string) string {
+	if canCast(checkBasicType(in.Type()), checkBasicType(out.Type())) {
+		return ""
+	}
+	inNamed, _ := in.Type().(*types.Named)
+	outNamed, _ := out.Type().(*types.Named)
+	if isTimePackage(inNamed) || isTimePackage(outNamed) {
+		return ""
+	}
+	inString := checkBasicType(in.Type()) != nil && checkBasicType(in.Type()).Info()&types.IsString > 0
+	outString := checkBasicType(out.Type()) != nil && checkBasicType(out.Type()).Info()&types.IsString > 0
+	value := prefix + "." + in.Name()
+	switch {
+	case inNamed != nil && outString:
+		if ok, needPointer := implements(inNamed, textMarshaler); ok {
+			if needPointer {
+				value = "&" + value
+			}
+			lastComment = "// text conversion"
+			lastError = true
+			return "conversion.MarshalText[" + currentPrinter.TypeString(out.Type()) + "](" + value + ", " + strconv.Quote(name) + ")"
+		}
+		// the String method is only used if the string can be converted back
+		ok, _ := implements(inNamed, stringer)
+		if canUnmarshal, _ := implements(inNamed, textUnmarshaler); ok && canUnmarshal {
+			// arg is a pointer, so the field is addressable for the methods
+			// with pointer receivers
+			lastComment = "// text conversion"
+			lastError = false
+			return renderCast(value+".String()", types.Typ[types.String], out.Type())
+		}
+
+	case outNamed != nil && inString:
+		// UnmarshalText must have a pointer receiver to change the value
+		if ok, _ := implements(outNamed, textUnmarshaler); ok {
+			lastComment = "// text conversion"
+			lastError = true
+			return "conversion.UnmarshalText[" + currentPrinter.TypeString(outNamed) + "](" +
+				renderCast(value, in.Type(), types.Typ[types.String]) + ", " + strconv.Quote(name) + ")"
+		}
+	}
+	return ""
+}
+
+func isTimePackage(named *types.Named) bool {
+	return named != nil && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time"
+}
@@ -0,0 +1,145 @@
+package plugin
+
+import (
+	"go/types"
+	"strconv"
+
+	"github.com/olvrng/ggen"
+)
+
+// timeUnit is the unit of the int64 fields which are converted from and to
+// time.Time and time.Duration. The conversions are enabled with the time
+// option of the +gen:convert directive:
+//
+//	// +gen:convert: example.com/api -> example.com/model; time=millis
+type timeUnit string
+
+const (
+	// TimeSeconds converts int64 fields as unix seconds. It is the unit of the
+	// time option without a value.
+	TimeSeconds timeUnit = "seconds"
+
+	// TimeMillis converts int64 fields as unix milliseconds.
+	TimeMillis timeUnit = "millis"
+)
+
+func parseTimeUnit(input string) (timeUnit, error) {
+	switch s := timeUnit(input); s {
+	case "":
+		return TimeSeconds, nil
+	case TimeSeconds, TimeMillis:
+		return s, nil
+	default:
+		return "", ggen.Errorf(nil, "invalid time unit (%v)", input)
+	}
+}
+
+type timeKind int
+
+const (
+	timeNone timeKind = iota
+	timeTime
+	timeTimePtr
+	timeDuration
+	timeInt64
+	timeString
+)
+
+func isTimeType(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == name
+}
+
+func getTimeKind(typ types.Type) timeKind {
+	switch {
+	case isTimeType(typ, "Time"):
+		return timeTime
+	case isTimeType(typ, "Duration"):
+		return timeDuration
+	}
+	if ptr, ok := typ.(*types.Pointer); ok && isTimeType(ptr.Elem(), "Time") {
+		return timeTimePtr
+	}
+	if basic := checkBasicType(typ); basic != nil {
+		switch basic.Kind() {
+		case types.Int64:
+			return timeInt64
+		case types.String:
+			return timeString
+		}
+	}
+	return timeNone
+}
+
+// renderTimeConversion renders the conversion between time.Time, *time.Time,
+// time.Duration, int64 and string fields, if the package is generated with the
+// time option. Parsing a string, and converting a time or a duration to an
+// int64 which can not hold its precision, return an error.
+func renderTimeConversion(in, out *types.Var, prefix, name string) string {
+	if currentOptions.time == "" {
+		return ""
+	}
+	inKind, outKind := getTimeKind(in.Type()), getTimeKind(out.Type())
+	if inKind == timeNone || outKind == timeNone || inKind == outKind {
+		return ""
+	}
+	p := currentPrinter
+	value := prefix + "." + in.Name()
+	if _, ok := in.Type().(*types.Named); ok && (inKind == timeInt64 || inKind == timeString) {
+		value = types.Typ[checkBasicType(in.Type()).Kind()].Name() + "(" + value + ")"
+	}
+	// cast renders the conversion to a named string type
+	cast := func(expr string) string {
+		if _, ok := out.Type().(*types.Named); ok {
+			return p.TypeString(out.Type()) + "(" + expr + ")"
+		}
+		return expr
+	}
+	outType, quoted := p.TypeString(out.Type()), strconv.Quote(name)
+	toUnix, fromUnix, unit := "conversion.TimeToUnix", "conversion.UnixToTime", "time.Second"
+	if currentOptions.time == TimeMillis {
+		toUnix, fromUnix, unit = "conversion.TimeToUnixMilli", "conversion.UnixMilliToTime", "time.Millisecond"
+	}
+
+	lastComment = "// time conversion"
+	lastError = false
+	switch {
+	case inKind == timeTime && outKind == timeTimePtr:
+		return "conversion.TimePtr(" + value + ")"
+	case inKind == timeTimePtr && outKind == timeTime:
+		return "conversion.TimeValue(" + value + ")"
+
+	case inKind == timeTime && outKind == timeInt64:
+		lastError = true
+		return toUnix + "[" + outType + "](" + value + ", " + quoted + ")"
+	case inKind == timeTimePtr && outKind == timeInt64:
+		lastError = true
+		return toUnix + "[" + outType + "](conversion.TimeValue(" + value + "), " + quoted + ")"
+	case inKind == timeInt64 && outKind == timeTime:
+		return fromUnix + "(" + value + ")"
+	case inKind == timeInt64 && outKind == timeTimePtr:
+		return "conversion.TimePtr(" + fromUnix + "(" + value + "))"
+
+	case inKind == timeTime && outKind == timeString:
+		return cast("conversion.TimeToString(" + value + ")")
+	case inKind == timeTimePtr && outKind == timeString:
+		return cast("conversion.TimeToString(conversion.TimeValue(" + value + "))")
+	case inKind == timeString && outKind == timeTime:
+		lastError = true
+		return "conversion.StringToTime(" + value + ", " + quoted + ")"
+	case inKind == timeString && outKind == timeTimePtr:
+		lastError = true
+		return "conversion.StringToTimePtr(" + value + ", " + quoted + ")"
+
+	case inKind == timeDuration && outKind == timeInt64:
+		p.Import("time", "time")
+		lastError = true
+		return "conversion.DurationToInt[" + outType + "](" + value + ", " + unit + ", " + quoted + ")"
+	case inKind == timeInt64 && outKind == timeDuration:
+		p.Import("time", "time")
+		lastError = true
+		return "conversion.IntToDuration(" + value + ", " + unit + ", " + quoted + ")"
+	}
+	lastComment = ""
+	return ""
+}
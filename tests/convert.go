@@ -1,7 +1,8 @@
 package tests
 
 import (
-	"strconv"
+	"database/sql"
+	"time"
 )
 
 //go:generate go run github.com/olvrng/ggen-convert/cmd/ggen-convert github.com/olvrng/ggen-convert/tests
@@ -26,11 +27,21 @@ type A struct {
 	Ep  *E
 	Es  []E
 	Eps []*E
+
+	Labels  map[string]string
+	CMap    map[string]*C0
+	CValues map[string]C0
+	Keys    map[S]int
+
+	Cv  C0
+	Cvs []C0
+	Cp  *C0
+	Cq  C0
 }
 
 // +convert:type=A
 type B struct {
-	Value   string
+	Value   string // +convert:strconv
 	Int     int32
 	String  S
 	Strings []string
@@ -43,6 +54,16 @@ type B struct {
 	Ep  *E
 	Es  []E
 	Eps []*E
+
+	Labels  map[string]string
+	CMap    map[string]*C1
+	CValues map[S]C1
+	Keys    map[string]int
+
+	Cv  C1
+	Cvs []C1
+	Cp  C1
+	Cq  *C1
 }
 
 type C0 struct {
@@ -51,7 +72,7 @@ type C0 struct {
 
 // +convert:type=C0
 type C1 struct {
-	Value string
+	Value string // +convert:strconv
 }
 
 // +convert:type=C0
@@ -79,26 +100,228 @@ type E struct {
 	Value string
 }
 
-func ConvertAB(a *A, b *B) {
-	convert_A_B(a, b)
-	b.Value = strconv.Itoa(a.Value)
+type User struct {
+	UserID   string
+	FullName string
+	Email    string
+}
+
+// +convert:type=User
+type UserInfo struct {
+	// +convert:field=UserID
+	ID    string
+	Name  string `convert:"FullName"`
+	Email string
+}
+
+// +convert:create=User
+type CreateUserArgs struct {
+	Name  string `convert:"FullName"`
+	Email string
+}
+
+// +convert:update=User(UserID)
+type UpdateUserArgs struct {
+	ID   string // +convert:field=UserID
+	Name string `convert:"FullName"`
+}
+
+type Image struct {
+	ImageID  string
+	ImageURL string
+	AltText  string
+}
+
+// +convert:type=Image
+// +convert:match=initialism
+type ImageInfo struct {
+	ImageId  string
+	ImageUrl string
+	AltText  string
+}
+
+// +convert:type=Image
+// +convert:match=snake
+type ImageRow struct {
+	Image_ID  string
+	Image_URL string
+	Alt_Text  string
+}
+
+type Address struct {
+	City   string
+	Street string
+	Zip    int
+}
+
+type Person struct {
+	Name    string
+	Address *Address
+	Home    Address
+}
+
+// NullString is applied on the field which it updates.
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+func (n NullString) Apply(s string) string {
+	if n.Valid {
+		return n.String
+	}
+	return s
+}
+
+// +convert:update=Person(Name)
+type UpdatePersonArgs struct {
+	Name string
+
+	// +convert:field=Address.City
+	City     NullString
+	HomeZip  string  // +convert:strconv
+	HomeCity *string // +convert:nil=keep
+}
+
+// +convert:type=Person
+type PersonInfo struct {
+	Name string
+
+	// +convert:field=Address.City
+	City          string
+	AddressStreet string
+	HomeCity      string
+	HomeZip       int
+}
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+	StatusArchived Status = "archived"
+)
+
+// +convert:enum=default
+type StatusCode int
+
+const (
+	// +convert:default
+	StatusCodeUnknown StatusCode = iota
+	StatusCodeActive
+	StatusCodeInactive
+)
+
+// Cents and Amount have unrelated constants, so they are not enums.
+type Cents int64
+
+const ZeroCents Cents = 0
+
+type Amount int64
+
+const MaxAmount Amount = 1e9
+
+type Job struct {
+	Name   string
+	Status Status
+	Budget Cents
+}
+
+// +convert:type=Job
+type JobRow struct {
+	Name   string
+	Status StatusCode
+	Budget Amount
+}
+
+type Profile struct {
+	Name    string
+	Age     int32
+	Score   float64
+	Country S
+	Bio     string
+	Email   string
 }
 
-func ConvertC01(c0 *C0) *C1 {
+// +convert:type=Profile
+type ProfileMessage struct {
+	Name    *string
+	Age     *int64
+	Score   *float64
+	Country *string
+	Bio     *string // +convert:nil=keep
+	Email   *string // +convert:nil=error
+}
+
+type Metrics struct {
+	Count  int64
+	Ratio  float64
+	Score  float64
+	Size   uint32
+	Visits int32
+	Hits   *int64
+	Misses sql.NullInt64
+	Peak   *int64
+	Level  int64
+	Errors *float64
+	Limit  int64
+}
+
+// +convert:type=Metrics
+type MetricsRow struct {
+	Count  int32         // +convert:checked
+	Ratio  float32       // +convert:checked
+	Score  int32         // +convert:checked
+	Size   int16         // +convert:checked
+	Visits int64         // +convert:checked
+	Hits   int32         // +convert:checked
+	Misses int32         // +convert:checked
+	Peak   *int16        // +convert:checked
+	Level  sql.NullInt16 // +convert:checked
+	Errors *int32        // +convert:checked
+	Limit  *int8         // +convert:checked
+}
+
+// NullS has a Valid field and a value field like the sql.Null types.
+type NullS struct {
+	S     S
+	Valid bool
+}
+
+type Customer struct {
+	Name     sql.NullString
+	Age      sql.NullInt64
+	Verified sql.NullBool
+	JoinedAt sql.NullTime
+	Score    sql.NullFloat64
+	Nickname NullS
+}
+
+// +convert:type=Customer
+type CustomerInfo struct {
+	Name     *string
+	Age      int32
+	Verified *bool
+	JoinedAt *time.Time
+	Score    float64
+	Nickname *string
+}
+
+func ConvertAB(a *A, b *B) error {
+	return convert_A_B(a, b)
+}
+
+func ConvertC01(c0 *C0) (*C1, error) {
 	if c0 == nil {
-		return nil
+		return nil, nil
 	}
 	var c1 C1
-	convert_C0_C1(c0, &c1)
-	c1.Value = strconv.Itoa(c0.Value)
-	return &c1
+	if err := convert_C0_C1(c0, &c1); err != nil {
+		return nil, err
+	}
+	return &c1, nil
 }
 
-func ConvertC10(c1 *C1, c0 *C0) *C0 {
-	if c1 == nil {
-		return nil
-	}
-	convert_C1_C0(c1, c0)
-	c0.Value, _ = strconv.Atoi(c1.Value)
-	return c0
+func ConvertC10(c1 *C1, c0 *C0) error {
+	return convert_C1_C0(c1, c0)
 }
@@ -1,7 +1,11 @@
 package tests
 
 import (
+	"database/sql"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +32,11 @@ func TestConvert(t *testing.T) {
 			Ep:  &E{"first"},
 			Es:  []E{{"second"}, {"third"}},
 			Eps: []*E{{"second"}, {"third"}},
+
+			Labels:  map[string]string{"env": "prod"},
+			CMap:    map[string]*C0{"one": {1}, "nil": nil},
+			CValues: map[string]C0{"two": {2}},
+			Keys:    map[S]int{"three": 3},
 		}
 		err := scheme.Convert(a, &b)
 		require.NoError(t, err)
@@ -43,6 +52,36 @@ func TestConvert(t *testing.T) {
 		assert.Equal(t, b.Ep.Value, "first")
 		assert.EqualValues(t, b.Es, []E{{"second"}, {"third"}})
 		assert.EqualValues(t, b.Eps, []*E{{"second"}, {"third"}})
+		assert.Equal(t, map[string]string{"env": "prod"}, b.Labels)
+		assert.Equal(t, map[string]*C1{"one": {"1"}, "nil": nil}, b.CMap)
+		assert.Equal(t, map[S]C1{"two": {"2"}}, b.CValues)
+		assert.Equal(t, map[string]int{"three": 3}, b.Keys)
+		assert.Equal(t, C1{"0"}, b.Cv)
+		assert.Nil(t, b.Cvs)
+		assert.Equal(t, C1{}, b.Cp)
+		assert.Equal(t, &C1{"0"}, b.Cq)
+	})
+	t.Run("B to A (maps and values)", func(t *testing.T) {
+		var a A
+		b := &B{
+			Value:   "0",
+			CValues: map[S]C1{"two": {"2"}},
+			Cv:      C1{"1"},
+			Cvs:     []C1{{"3"}, {"4"}},
+			Cp:      C1{"5"},
+		}
+		err := scheme.Convert(b, &a)
+		require.NoError(t, err)
+		assert.Nil(t, a.CMap)
+		assert.Equal(t, map[string]C0{"two": {2}}, a.CValues)
+		assert.Equal(t, C0{1}, a.Cv)
+		assert.Equal(t, []C0{{3}, {4}}, a.Cvs)
+		assert.Equal(t, &C0{5}, a.Cp)
+		assert.Equal(t, C0{}, a.Cq, "nil pointer is converted to zero value")
+
+		b.CMap = map[string]*C1{"one": {"one"}}
+		err = scheme.Convert(b, &a)
+		require.Error(t, err)
 	})
 	t.Run("[]*A to []*B", func(t *testing.T) {
 		var bs []*B
@@ -53,6 +92,61 @@ func TestConvert(t *testing.T) {
 		require.Equal(t, bs[0].Value, "10")
 		require.Equal(t, bs[1].Value, "20")
 	})
+	t.Run("[]C0 to []C1", func(t *testing.T) {
+		var c1s []C1
+		err := scheme.Convert([]C0{{10}, {20}}, &c1s)
+		require.NoError(t, err)
+		assert.Equal(t, []C1{{"10"}, {"20"}}, c1s)
+	})
+	t.Run("map[string]*C0 to map[string]*C1", func(t *testing.T) {
+		c1s, err := conversion.ToMap[C1](scheme, map[string]*C0{"a": {10}, "b": nil})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*C1{"a": {"10"}, "b": nil}, c1s)
+	})
+	t.Run("map[int]*C1 to map[int]*C0 (error)", func(t *testing.T) {
+		var c0s map[int]*C0
+		err := scheme.Convert(map[int]*C1{1: {"one"}}, &c0s)
+		require.Error(t, err)
+		assert.Nil(t, c0s)
+	})
+	t.Run("C0 to C1 (custom func returning a new object)", func(t *testing.T) {
+		// the generated func returns the result of ConvertC01 and leaves out
+		// unchanged
+		c1 := C1{"unchanged"}
+		result, err := Convert_C0_C1(&C0{10}, &c1)
+		require.NoError(t, err)
+		assert.Equal(t, &C1{"10"}, result)
+		assert.Equal(t, C1{"unchanged"}, c1)
+
+		// the scheme copies the result into out
+		require.NoError(t, scheme.Convert(&C0{10}, &c1))
+		assert.Equal(t, C1{"10"}, c1)
+	})
+	t.Run("C0 to C1 (generic)", func(t *testing.T) {
+		c1, err := conversion.To[C1](scheme, &C0{10})
+		require.NoError(t, err)
+		assert.Equal(t, "10", c1.Value)
+
+		c0s, err := conversion.ToSlice[C0](scheme, []*C1{{"10"}, {"20"}})
+		require.NoError(t, err)
+		assert.EqualValues(t, []*C0{{10}, {20}}, c0s)
+	})
+	t.Run("B to A (error)", func(t *testing.T) {
+		var a A
+		b := &B{Value: "10", C: &C1{"invalid"}}
+		err := scheme.Convert(b, &a)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `C0.Value: strconv.ParseInt: parsing "invalid"`)
+
+		err = scheme.Convert(&B{Value: "ten"}, &a)
+		assert.EqualError(t, err, `A.Value: strconv.ParseInt: parsing "ten": invalid syntax`)
+	})
+	t.Run("[]*C1 to []*C0 (error)", func(t *testing.T) {
+		var cs []*C0
+		err := scheme.Convert([]*C1{{"10"}, {"invalid"}}, &cs)
+		require.Error(t, err)
+		assert.Nil(t, cs)
+	})
 	t.Run("Embedded", func(t *testing.T) {
 		t.Run("C0 to C2", func(t *testing.T) {
 			from := &C0{100}
@@ -84,3 +178,269 @@ func TestConvert(t *testing.T) {
 		})
 	})
 }
+
+func TestConvertRenamedFields(t *testing.T) {
+	t.Run("UserInfo to User", func(t *testing.T) {
+		user, err := conversion.To[User](scheme, &UserInfo{ID: "1", Name: "Alice", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, &User{UserID: "1", FullName: "Alice", Email: "alice@example.com"}, user)
+	})
+	t.Run("User to UserInfo", func(t *testing.T) {
+		info, err := conversion.To[UserInfo](scheme, &User{UserID: "1", FullName: "Alice"})
+		require.NoError(t, err)
+		assert.Equal(t, &UserInfo{ID: "1", Name: "Alice"}, info)
+	})
+	t.Run("create", func(t *testing.T) {
+		user, err := Apply_CreateUserArgs_User(&CreateUserArgs{Name: "Alice", Email: "alice@example.com"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &User{FullName: "Alice", Email: "alice@example.com"}, user)
+	})
+	t.Run("update", func(t *testing.T) {
+		user := &User{UserID: "1", FullName: "Alice", Email: "alice@example.com"}
+		_, err := Apply_UpdateUserArgs_User(&UpdateUserArgs{ID: "2", Name: "Bob"}, user)
+		require.NoError(t, err)
+		assert.Equal(t, &User{UserID: "1", FullName: "Bob", Email: "alice@example.com"}, user)
+	})
+}
+
+func TestConvertMatchStrategies(t *testing.T) {
+	image := &Image{ImageID: "1", ImageURL: "https://example.com/1.png", AltText: "one"}
+	t.Run("initialism", func(t *testing.T) {
+		info, err := conversion.To[ImageInfo](scheme, image)
+		require.NoError(t, err)
+		assert.Equal(t, &ImageInfo{ImageId: "1", ImageUrl: "https://example.com/1.png", AltText: "one"}, info)
+	})
+	t.Run("snake", func(t *testing.T) {
+		row, err := conversion.To[ImageRow](scheme, image)
+		require.NoError(t, err)
+		assert.Equal(t, &ImageRow{Image_ID: "1", Image_URL: "https://example.com/1.png", Alt_Text: "one"}, row)
+	})
+}
+
+func TestConvertNestedFields(t *testing.T) {
+	t.Run("flatten", func(t *testing.T) {
+		person := &Person{
+			Name:    "Alice",
+			Address: &Address{City: "Paris", Street: "Rue de Rivoli"},
+			Home:    Address{City: "Lyon", Zip: 69001},
+		}
+		info, err := conversion.To[PersonInfo](scheme, person)
+		require.NoError(t, err)
+		assert.Equal(t, &PersonInfo{Name: "Alice", City: "Paris", AddressStreet: "Rue de Rivoli", HomeCity: "Lyon", HomeZip: 69001}, info)
+	})
+	t.Run("flatten (nil pointer)", func(t *testing.T) {
+		info, err := conversion.To[PersonInfo](scheme, &Person{Name: "Alice"})
+		require.NoError(t, err)
+		assert.Equal(t, &PersonInfo{Name: "Alice"}, info)
+	})
+	t.Run("unflatten", func(t *testing.T) {
+		person, err := conversion.To[Person](scheme, &PersonInfo{Name: "Alice", AddressStreet: "Rue de Rivoli", HomeZip: 69001})
+		require.NoError(t, err)
+		assert.Equal(t, &Person{Name: "Alice", Address: &Address{Street: "Rue de Rivoli"}, Home: Address{Zip: 69001}}, person)
+	})
+	t.Run("unflatten (zero values)", func(t *testing.T) {
+		person, err := conversion.To[Person](scheme, &PersonInfo{Name: "Alice"})
+		require.NoError(t, err)
+		assert.Nil(t, person.Address)
+	})
+	t.Run("update", func(t *testing.T) {
+		person := &Person{Name: "Alice", Home: Address{City: "Lyon", Zip: 69001}}
+		_, err := Apply_UpdatePersonArgs_Person(&UpdatePersonArgs{Name: "Bob", HomeZip: "75001"}, person)
+		require.NoError(t, err)
+		assert.Equal(t, &Person{Name: "Alice", Home: Address{City: "Lyon", Zip: 75001}}, person)
+
+		city := "Paris"
+		args := &UpdatePersonArgs{City: NullString{String: "Paris", Valid: true}, HomeZip: "75001", HomeCity: &city}
+		_, err = Apply_UpdatePersonArgs_Person(args, person)
+		require.NoError(t, err)
+		assert.Equal(t, &Person{Name: "Alice", Address: &Address{City: "Paris"}, Home: Address{City: "Paris", Zip: 75001}}, person)
+	})
+	t.Run("update (error)", func(t *testing.T) {
+		_, err := Apply_UpdatePersonArgs_Person(&UpdatePersonArgs{HomeZip: "bad"}, &Person{})
+		assert.EqualError(t, err, `Person.Home.Zip: strconv.ParseInt: parsing "bad": invalid syntax`)
+	})
+}
+
+func TestConvertEnums(t *testing.T) {
+	t.Run("Job to JobRow", func(t *testing.T) {
+		row, err := conversion.To[JobRow](scheme, &Job{Name: "build", Status: StatusInactive})
+		require.NoError(t, err)
+		assert.Equal(t, &JobRow{Name: "build", Status: StatusCodeInactive}, row)
+	})
+	t.Run("Job to JobRow (default)", func(t *testing.T) {
+		row, err := conversion.To[JobRow](scheme, &Job{Name: "build", Status: StatusArchived})
+		require.NoError(t, err)
+		assert.Equal(t, &JobRow{Name: "build", Status: StatusCodeUnknown}, row)
+	})
+	t.Run("JobRow to Job", func(t *testing.T) {
+		job, err := conversion.To[Job](scheme, &JobRow{Name: "build", Status: StatusCodeActive})
+		require.NoError(t, err)
+		assert.Equal(t, &Job{Name: "build", Status: StatusActive}, job)
+	})
+	t.Run("JobRow to Job (error)", func(t *testing.T) {
+		_, err := conversion.To[Job](scheme, &JobRow{Name: "build", Status: StatusCodeUnknown})
+		assert.EqualError(t, err, "can not convert StatusCode(0) to Status")
+	})
+	t.Run("named types with unrelated constants", func(t *testing.T) {
+		row, err := conversion.To[JobRow](scheme, &Job{Status: StatusActive, Budget: 1500})
+		require.NoError(t, err)
+		assert.Equal(t, Amount(1500), row.Budget)
+
+		job, err := conversion.To[Job](scheme, &JobRow{Status: StatusCodeActive, Budget: MaxAmount})
+		require.NoError(t, err)
+		assert.Equal(t, Cents(1e9), job.Budget)
+	})
+}
+
+func TestConvertPointerFields(t *testing.T) {
+	t.Run("Profile to ProfileMessage", func(t *testing.T) {
+		msg, err := conversion.To[ProfileMessage](scheme, &Profile{Name: "Alice", Age: 30, Country: "FR"})
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", *msg.Name)
+		assert.Equal(t, int64(30), *msg.Age)
+		assert.Equal(t, "FR", *msg.Country)
+		assert.Equal(t, "", *msg.Email)
+	})
+	t.Run("ProfileMessage to Profile", func(t *testing.T) {
+		email := "alice@example.com"
+		age := int64(30)
+		profile := &Profile{Name: "Bob", Bio: "unchanged"}
+		_, err := Convert_ProfileMessage_Profile(&ProfileMessage{Age: &age, Email: &email}, profile)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Age: 30, Bio: "unchanged", Email: email}, profile)
+	})
+	t.Run("ProfileMessage to Profile (required)", func(t *testing.T) {
+		_, err := conversion.To[Profile](scheme, &ProfileMessage{})
+		assert.EqualError(t, err, "Profile.Email is required")
+	})
+}
+
+func TestConvertCheckedNumbers(t *testing.T) {
+	t.Run("in range", func(t *testing.T) {
+		row, err := conversion.To[MetricsRow](scheme, &Metrics{Count: 10, Ratio: 0.5, Score: 9.9, Size: 100, Visits: 3})
+		require.NoError(t, err)
+		assert.Equal(t, &MetricsRow{Count: 10, Ratio: 0.5, Score: 9, Size: 100, Visits: 3, Level: sql.NullInt16{Valid: true}, Limit: ptr(int8(0))}, row)
+	})
+	t.Run("in range (pointer and null fields)", func(t *testing.T) {
+		row, err := conversion.To[MetricsRow](scheme, &Metrics{
+			Hits: ptr(int64(5)), Misses: sql.NullInt64{Int64: 2, Valid: true}, Peak: ptr(int64(7)),
+			Level: 3, Errors: ptr(1.5), Limit: 4,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &MetricsRow{
+			Hits: 5, Misses: 2, Peak: ptr(int16(7)),
+			Level: sql.NullInt16{Int16: 3, Valid: true}, Errors: ptr(int32(1)), Limit: ptr(int8(4)),
+		}, row)
+	})
+	for _, tt := range []struct {
+		name    string
+		metrics Metrics
+		err     string
+	}{
+		{"narrowing", Metrics{Count: math.MaxInt32 + 1}, "MetricsRow.Count: value 2147483648 is out of range of int32"},
+		{"float", Metrics{Ratio: math.MaxFloat64}, "MetricsRow.Ratio: value 1.7976931348623157e+308 is out of range of float32"},
+		{"float to int", Metrics{Score: 1e10}, "MetricsRow.Score: value 1e+10 is out of range of int32"},
+		{"float to int (NaN)", Metrics{Score: math.NaN()}, "MetricsRow.Score: value NaN is out of range of int32"},
+		{"sign", Metrics{Size: math.MaxUint32}, "MetricsRow.Size: value 4294967295 is out of range of int16"},
+		{"pointer to value", Metrics{Hits: ptr(int64(math.MaxInt64))}, "MetricsRow.Hits: value 9223372036854775807 is out of range of int32"},
+		{"null to value", Metrics{Misses: sql.NullInt64{Int64: -1 << 40, Valid: true}}, "MetricsRow.Misses: value -1099511627776 is out of range of int32"},
+		{"pointer to pointer", Metrics{Peak: ptr(int64(1 << 20))}, "MetricsRow.Peak: value 1048576 is out of range of int16"},
+		{"value to null", Metrics{Level: 1 << 20}, "MetricsRow.Level: value 1048576 is out of range of int16"},
+		{"float pointer", Metrics{Errors: ptr(1e12)}, "MetricsRow.Errors: value 1e+12 is out of range of int32"},
+		{"value to pointer", Metrics{Limit: 300}, "MetricsRow.Limit: value 300 is out of range of int8"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := conversion.To[MetricsRow](scheme, &tt.metrics)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+	t.Run("negative to unsigned", func(t *testing.T) {
+		_, err := conversion.To[Metrics](scheme, &MetricsRow{Size: -1})
+		assert.EqualError(t, err, "Metrics.Size: value -1 is out of range of uint32")
+	})
+}
+
+func TestConvertNullFields(t *testing.T) {
+	joinedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	name, nickname := "Alice", "ali"
+	customer := &Customer{
+		Name:     sql.NullString{String: name, Valid: true},
+		Age:      sql.NullInt64{Int64: 30, Valid: true},
+		JoinedAt: sql.NullTime{Time: joinedAt, Valid: true},
+		Score:    sql.NullFloat64{Float64: 4.5, Valid: true},
+		Nickname: NullS{S: "ali", Valid: true},
+	}
+	info := &CustomerInfo{Name: &name, Age: 30, JoinedAt: &joinedAt, Score: 4.5, Nickname: &nickname}
+
+	t.Run("Customer to CustomerInfo", func(t *testing.T) {
+		out, err := conversion.To[CustomerInfo](scheme, customer)
+		require.NoError(t, err)
+		assert.Equal(t, info, out)
+	})
+	t.Run("CustomerInfo to Customer", func(t *testing.T) {
+		out, err := conversion.To[Customer](scheme, info)
+		require.NoError(t, err)
+		assert.Equal(t, customer, out)
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		out, err := conversion.To[CustomerInfo](scheme, &Customer{Name: sql.NullString{String: "ignored"}})
+		require.NoError(t, err)
+		assert.Equal(t, &CustomerInfo{}, out)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRegisterConversions(t *testing.T) {
+	for _, pair := range [][2]interface{}{
+		{(*A)(nil), (*B)(nil)},
+		{(*B)(nil), (*A)(nil)},
+		{([]*A)(nil), (*[]*B)(nil)},
+		{([]*B)(nil), (*[]*A)(nil)},
+		{(*C0)(nil), (*C2)(nil)},
+		{([]*C3)(nil), (*[]*C0)(nil)},
+		{([]C0)(nil), (*[]C1)(nil)},
+		{(map[string]*D0)(nil), (*map[string]*D1)(nil)},
+	} {
+		assert.True(t, scheme.Has(pair[0], pair[1]), "%T -> %T", pair[0], pair[1])
+	}
+	assert.False(t, scheme.Has((*C1)(nil), (*C2)(nil)))
+
+	var expected []conversion.TypePair
+	for _, pairs := range [][]conversion.TypePair{
+		registeredPairs[A, B](), registeredPairs[B, A](),
+		registeredPairs[C0, C1](), registeredPairs[C1, C0](),
+		registeredPairs[C0, C2](), registeredPairs[C2, C0](),
+		registeredPairs[C0, C3](), registeredPairs[C3, C0](),
+		registeredPairs[D0, D1](), registeredPairs[D1, D0](),
+		registeredPairs[Customer, CustomerInfo](), registeredPairs[CustomerInfo, Customer](),
+		registeredPairs[Image, ImageInfo](), registeredPairs[ImageInfo, Image](),
+		registeredPairs[Image, ImageRow](), registeredPairs[ImageRow, Image](),
+		registeredPairs[Job, JobRow](), registeredPairs[JobRow, Job](),
+		registeredPairs[Metrics, MetricsRow](), registeredPairs[MetricsRow, Metrics](),
+		registeredPairs[Person, PersonInfo](), registeredPairs[PersonInfo, Person](),
+		registeredPairs[Profile, ProfileMessage](), registeredPairs[ProfileMessage, Profile](),
+		registeredPairs[User, UserInfo](), registeredPairs[UserInfo, User](),
+		registeredPairs[CreateUserArgs, User]()[:1],
+		registeredPairs[UpdateUserArgs, User]()[:1],
+		registeredPairs[UpdatePersonArgs, Person]()[:1],
+	} {
+		expected = append(expected, pairs...)
+	}
+	assert.ElementsMatch(t, expected, scheme.Pairs())
+}
+
+// registeredPairs returns the pairs registered by the generated code for a
+// conversion from A to B: *A to *B, []*A to *[]*B, []A to *[]B and maps of *A
+// to *B.
+func registeredPairs[A, B any]() []conversion.TypePair {
+	arg, out := reflect.TypeOf((*A)(nil)).Elem(), reflect.TypeOf((*B)(nil)).Elem()
+	return []conversion.TypePair{
+		{Arg: arg, Out: out},
+		{Slice: true, Arg: arg, Out: out},
+		{Slice: true, Value: true, Arg: arg, Out: out},
+		{Map: true, Arg: arg, Out: out},
+	}
+}
@@ -0,0 +1,29 @@
+package strict
+
+//go:generate go run github.com/olvrng/ggen-convert/cmd/ggen-convert github.com/olvrng/ggen-convert/tests/strict
+
+// +gen:convert: github.com/olvrng/ggen-convert/tests/strict; strict
+
+type Account struct {
+	ID    string
+	Name  string
+	Email string
+
+	// +convert:ignore
+	Password string
+}
+
+// +convert:type=Account
+type AccountInfo struct {
+	ID    string
+	Name  string
+	Email string
+
+	Token string // +convert:ignore
+}
+
+// +convert:create=Account
+type CreateAccountArgs struct {
+	Name  string
+	Email string
+}
@@ -0,0 +1,38 @@
+package strict
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/olvrng/ggen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ggen_convert "github.com/olvrng/ggen-convert"
+)
+
+func TestIgnoredFields(t *testing.T) {
+	account := &Account{ID: "1", Name: "Alice", Password: "secret"}
+	info, err := Convert_Account_AccountInfo(account, &AccountInfo{Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, &AccountInfo{ID: "1", Name: "Alice", Token: "token"}, info)
+
+	_, err = Convert_AccountInfo_Account(&AccountInfo{ID: "2", Token: "token"}, account)
+	require.NoError(t, err)
+	assert.Equal(t, &Account{ID: "2", Password: "secret"}, account)
+}
+
+func TestStrictMode(t *testing.T) {
+	require.NoError(t, ggen.RegisterPlugin(ggen_convert.New()))
+	err := ggen.Start(ggen.Config{}, "github.com/olvrng/ggen-convert/tests/strict/unmatched")
+	require.Error(t, err)
+
+	file, err2 := filepath.Abs("unmatched/unmatched.go")
+	require.NoError(t, err2)
+	assert.Equal(t, fmt.Sprintf(`convert: convert package github.com/olvrng/ggen-convert/tests/strict/unmatched (strict): 4 fields are not converted:
+	%[1]v:11:2: Account.Tags: no matching field in AccountInfo
+	%[1]v:10:2: Account.Age: types do not match (chan int -> int)
+	%[1]v:19:2: AccountInfo.Role: no matching field in Account
+	%[1]v:18:2: AccountInfo.Age: types do not match (int -> chan int)`, file), err.Error())
+}
@@ -0,0 +1,23 @@
+// Package unmatched has fields which can not be converted. Generating it in
+// strict mode fails, see TestStrictMode.
+package unmatched
+
+// +gen:convert: github.com/olvrng/ggen-convert/tests/strict/unmatched; strict
+
+type Account struct {
+	ID   string
+	Name string
+	Age  int
+	Tags []string
+}
+
+// +convert:type=Account
+type AccountInfo struct {
+	ID   string
+	Name string
+	Age  chan int
+	Role string
+
+	// +convert:ignore
+	Token string
+}
@@ -0,0 +1,139 @@
+//go:build !generator
+// +build !generator
+
+// Code generated by generator convert. DO NOT EDIT.
+
+package strict
+
+import (
+	conversion "github.com/olvrng/ggen-convert/conversion"
+)
+
+/*
+Custom conversions: (none)
+
+Ignored functions: (none)
+*/
+
+func RegisterConversions(s *conversion.Scheme) {
+	registerConversions(s)
+}
+
+func registerConversions(s *conversion.Scheme) {
+	conversion.Register(s, func(arg *AccountInfo, out *Account) error {
+		return conversion.CallInto(Convert_AccountInfo_Account, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_AccountInfos_Accounts)
+	conversion.RegisterValueSlice(s, func(arg *AccountInfo, out *Account) error {
+		return conversion.CallInto(Convert_AccountInfo_Account, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *AccountInfo, out *Account) error {
+		return conversion.CallInto(Convert_AccountInfo_Account, arg, out)
+	})
+	conversion.Register(s, func(arg *Account, out *AccountInfo) error {
+		return conversion.CallInto(Convert_Account_AccountInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Accounts_AccountInfos)
+	conversion.RegisterValueSlice(s, func(arg *Account, out *AccountInfo) error {
+		return conversion.CallInto(Convert_Account_AccountInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Account, out *AccountInfo) error {
+		return conversion.CallInto(Convert_Account_AccountInfo, arg, out)
+	})
+	conversion.Register(s, func(arg *CreateAccountArgs, out *Account) error {
+		return conversion.CallInto(Apply_CreateAccountArgs_Account, arg, out)
+	})
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests/strict.Account --//
+
+func Convert_AccountInfo_Account(arg *AccountInfo, out *Account) (*Account, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Account{}
+	}
+	if err := convert_AccountInfo_Account(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_AccountInfo_Account(arg *AccountInfo, out *Account) (err error) {
+	out.ID = arg.ID             // simple assign
+	out.Name = arg.Name         // simple assign
+	out.Email = arg.Email       // simple assign
+	out.Password = out.Password // ignored
+	return nil
+}
+
+func Convert_AccountInfos_Accounts(args []*AccountInfo) (outs []*Account, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Account, len(args))
+	outs = make([]*Account, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_AccountInfo_Account(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Account_AccountInfo(arg *Account, out *AccountInfo) (*AccountInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &AccountInfo{}
+	}
+	if err := convert_Account_AccountInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Account_AccountInfo(arg *Account, out *AccountInfo) (err error) {
+	out.ID = arg.ID       // simple assign
+	out.Name = arg.Name   // simple assign
+	out.Email = arg.Email // simple assign
+	out.Token = out.Token // ignored
+	return nil
+}
+
+func Convert_Accounts_AccountInfos(args []*Account) (outs []*AccountInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]AccountInfo, len(args))
+	outs = make([]*AccountInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Account_AccountInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Apply_CreateAccountArgs_Account(arg *CreateAccountArgs, out *Account) (*Account, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Account{}
+	}
+	if err := apply_CreateAccountArgs_Account(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func apply_CreateAccountArgs_Account(arg *CreateAccountArgs, out *Account) (err error) {
+	out.ID = out.ID             // no change
+	out.Name = arg.Name         // simple assign
+	out.Email = arg.Email       // simple assign
+	out.Password = out.Password // ignored
+	return nil
+}
@@ -0,0 +1,123 @@
+package text
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/olvrng/ggen-convert/cmd/ggen-convert github.com/olvrng/ggen-convert/tests/text
+
+// +gen:convert: github.com/olvrng/ggen-convert/tests/text; strconv
+
+type S string
+
+type Filter struct {
+	Page    int
+	Limit   uint16
+	MinRate float64
+	Active  bool
+	Offset  int64
+}
+
+// +convert:type=Filter
+type Query struct {
+	Page    string
+	Limit   string
+	MinRate S
+	Active  string
+	Offset  S
+}
+
+// ID is formatted with a prefix, such as id-42.
+type ID int64
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte("id-" + strconv.FormatInt(int64(id), 10)), nil
+}
+
+func (id *ID) UnmarshalText(text []byte) error {
+	s := string(text)
+	if !strings.HasPrefix(s, "id-") {
+		return fmt.Errorf("invalid id %q", s)
+	}
+	v, err := strconv.ParseInt(s[len("id-"):], 10, 64)
+	*id = ID(v)
+	return err
+}
+
+type Money struct {
+	Cents int64
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d.%02d", m.Cents/100, m.Cents%100)
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	var units, cents int64
+	if _, err := fmt.Sscanf(string(text), "%d.%02d", &units, &cents); err != nil {
+		return err
+	}
+	m.Cents = units*100 + cents
+	return nil
+}
+
+// Code implements the text methods with pointer receivers.
+type Code struct {
+	value string
+}
+
+func (c *Code) MarshalText() ([]byte, error) {
+	if c.value == "" {
+		return nil, errors.New("empty code")
+	}
+	return []byte(strings.ToUpper(c.value)), nil
+}
+
+func (c *Code) UnmarshalText(text []byte) error {
+	c.value = strings.ToLower(string(text))
+	return nil
+}
+
+// Email has a String method, but is cast to and from string fields.
+type Email string
+
+func (e Email) String() string {
+	return "<" + string(e) + ">"
+}
+
+// Label has a String method but can not be parsed, so it is not converted.
+type Label struct {
+	name string
+}
+
+func (l Label) String() string {
+	return l.name
+}
+
+type Order struct {
+	ID    ID
+	Total Money
+	Code  Code
+	Email Email
+	Label Label
+
+	// the package is generated without the time option
+	CreatedAt time.Time
+	Timeout   time.Duration
+}
+
+// +convert:type=Order
+type OrderInfo struct {
+	ID    string
+	Total string
+	Code  S
+	Email string
+	Label string
+
+	CreatedAt string
+	Timeout   string
+}
@@ -0,0 +1,81 @@
+package text
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertStrconv(t *testing.T) {
+	filter := &Filter{Page: 2, Limit: 50, MinRate: 4.5, Active: true, Offset: -10}
+	query := &Query{Page: "2", Limit: "50", MinRate: "4.5", Active: "true", Offset: "-10"}
+
+	t.Run("Filter to Query", func(t *testing.T) {
+		out, err := Convert_Filter_Query(filter, nil)
+		require.NoError(t, err)
+		assert.Equal(t, query, out)
+	})
+	t.Run("Query to Filter", func(t *testing.T) {
+		out, err := Convert_Query_Filter(query, nil)
+		require.NoError(t, err)
+		assert.Equal(t, filter, out)
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := Convert_Query_Filter(&Query{Page: "two"}, nil)
+		assert.EqualError(t, err, `Filter.Page: strconv.ParseInt: parsing "two": invalid syntax`)
+
+		_, err = Convert_Query_Filter(&Query{Page: "1", Limit: "70000"}, nil)
+		assert.EqualError(t, err, `Filter.Limit: strconv.ParseUint: parsing "70000": value out of range`)
+	})
+}
+
+func TestConvertTextMarshalers(t *testing.T) {
+	t.Run("Order to OrderInfo", func(t *testing.T) {
+		info, err := Convert_Order_OrderInfo(&Order{ID: 42, Total: Money{Cents: 1250}, Code: Code{value: "abc"}}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &OrderInfo{ID: "id-42", Total: "12.50", Code: "ABC"}, info)
+	})
+	t.Run("Order to OrderInfo (error)", func(t *testing.T) {
+		_, err := Convert_Order_OrderInfo(&Order{ID: 42}, nil)
+		assert.EqualError(t, err, "OrderInfo.Code: empty code")
+	})
+	t.Run("OrderInfo to Order", func(t *testing.T) {
+		order, err := Convert_OrderInfo_Order(&OrderInfo{ID: "id-42", Total: "12.50", Code: "ABC"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &Order{ID: 42, Total: Money{Cents: 1250}, Code: Code{value: "abc"}}, order)
+	})
+	t.Run("named string with String method", func(t *testing.T) {
+		info, err := Convert_Order_OrderInfo(&Order{Code: Code{value: "abc"}, Email: "alice@example.com"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", info.Email)
+
+		order, err := Convert_OrderInfo_Order(info, nil)
+		require.NoError(t, err)
+		assert.Equal(t, Email("alice@example.com"), order.Email)
+	})
+	t.Run("OrderInfo to Order (error)", func(t *testing.T) {
+		_, err := Convert_OrderInfo_Order(&OrderInfo{ID: "42"}, nil)
+		assert.EqualError(t, err, `Order.ID: invalid id "42"`)
+	})
+	t.Run("time fields without the time option", func(t *testing.T) {
+		createdAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+		order := &Order{
+			ID: 42, Total: Money{Cents: 1250}, Code: Code{value: "abc"},
+			Label: Label{name: "gift"}, CreatedAt: createdAt, Timeout: time.Minute,
+		}
+		info, err := Convert_Order_OrderInfo(order, &OrderInfo{CreatedAt: "unchanged"})
+		require.NoError(t, err)
+		assert.Equal(t, "unchanged", info.CreatedAt)
+		assert.Empty(t, info.Timeout)
+		assert.Empty(t, info.Label)
+
+		info.CreatedAt, info.Timeout, info.Label = "", "", ""
+		out, err := Convert_OrderInfo_Order(info, order)
+		require.NoError(t, err)
+		assert.Equal(t, createdAt, out.CreatedAt)
+		assert.Equal(t, time.Minute, out.Timeout)
+		assert.Equal(t, Label{name: "gift"}, out.Label)
+	})
+}
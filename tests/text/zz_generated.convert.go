@@ -0,0 +1,237 @@
+//go:build !generator
+// +build !generator
+
+// Code generated by generator convert. DO NOT EDIT.
+
+package text
+
+import (
+	strconv "strconv"
+
+	conversion "github.com/olvrng/ggen-convert/conversion"
+)
+
+/*
+Custom conversions: (none)
+
+Ignored functions: (none)
+*/
+
+func RegisterConversions(s *conversion.Scheme) {
+	registerConversions(s)
+}
+
+func registerConversions(s *conversion.Scheme) {
+	conversion.Register(s, func(arg *Query, out *Filter) error {
+		return conversion.CallInto(Convert_Query_Filter, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Queries_Filters)
+	conversion.RegisterValueSlice(s, func(arg *Query, out *Filter) error {
+		return conversion.CallInto(Convert_Query_Filter, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Query, out *Filter) error {
+		return conversion.CallInto(Convert_Query_Filter, arg, out)
+	})
+	conversion.Register(s, func(arg *Filter, out *Query) error {
+		return conversion.CallInto(Convert_Filter_Query, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Filters_Queries)
+	conversion.RegisterValueSlice(s, func(arg *Filter, out *Query) error {
+		return conversion.CallInto(Convert_Filter_Query, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Filter, out *Query) error {
+		return conversion.CallInto(Convert_Filter_Query, arg, out)
+	})
+	conversion.Register(s, func(arg *OrderInfo, out *Order) error {
+		return conversion.CallInto(Convert_OrderInfo_Order, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_OrderInfos_Orders)
+	conversion.RegisterValueSlice(s, func(arg *OrderInfo, out *Order) error {
+		return conversion.CallInto(Convert_OrderInfo_Order, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *OrderInfo, out *Order) error {
+		return conversion.CallInto(Convert_OrderInfo_Order, arg, out)
+	})
+	conversion.Register(s, func(arg *Order, out *OrderInfo) error {
+		return conversion.CallInto(Convert_Order_OrderInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Orders_OrderInfos)
+	conversion.RegisterValueSlice(s, func(arg *Order, out *OrderInfo) error {
+		return conversion.CallInto(Convert_Order_OrderInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Order, out *OrderInfo) error {
+		return conversion.CallInto(Convert_Order_OrderInfo, arg, out)
+	})
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests/text.Filter --//
+
+func Convert_Query_Filter(arg *Query, out *Filter) (*Filter, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Filter{}
+	}
+	if err := convert_Query_Filter(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Query_Filter(arg *Query, out *Filter) (err error) {
+	if out.Page, err = conversion.ParseInt[int](arg.Page, 0, "Filter.Page"); err != nil {
+		return err
+	}
+	if out.Limit, err = conversion.ParseUint[uint16](arg.Limit, 16, "Filter.Limit"); err != nil {
+		return err
+	}
+	if out.MinRate, err = conversion.ParseFloat[float64](string(arg.MinRate), 64, "Filter.MinRate"); err != nil {
+		return err
+	}
+	if out.Active, err = conversion.ParseBool[bool](arg.Active, "Filter.Active"); err != nil {
+		return err
+	}
+	if out.Offset, err = conversion.ParseInt[int64](string(arg.Offset), 64, "Filter.Offset"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_Queries_Filters(args []*Query) (outs []*Filter, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Filter, len(args))
+	outs = make([]*Filter, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Query_Filter(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Filter_Query(arg *Filter, out *Query) (*Query, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Query{}
+	}
+	if err := convert_Filter_Query(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Filter_Query(arg *Filter, out *Query) (err error) {
+	out.Page = strconv.FormatInt(int64(arg.Page), 10)              // strconv conversion
+	out.Limit = strconv.FormatUint(uint64(arg.Limit), 10)          // strconv conversion
+	out.MinRate = S(strconv.FormatFloat(arg.MinRate, 'g', -1, 64)) // strconv conversion
+	out.Active = strconv.FormatBool(arg.Active)                    // strconv conversion
+	out.Offset = S(strconv.FormatInt(arg.Offset, 10))              // strconv conversion
+	return nil
+}
+
+func Convert_Filters_Queries(args []*Filter) (outs []*Query, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Query, len(args))
+	outs = make([]*Query, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Filter_Query(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests/text.Order --//
+
+func Convert_OrderInfo_Order(arg *OrderInfo, out *Order) (*Order, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Order{}
+	}
+	if err := convert_OrderInfo_Order(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_OrderInfo_Order(arg *OrderInfo, out *Order) (err error) {
+	if out.ID, err = conversion.UnmarshalText[ID](arg.ID, "Order.ID"); err != nil {
+		return err
+	}
+	if out.Total, err = conversion.UnmarshalText[Money](arg.Total, "Order.Total"); err != nil {
+		return err
+	}
+	if out.Code, err = conversion.UnmarshalText[Code](string(arg.Code), "Order.Code"); err != nil {
+		return err
+	}
+	out.Email = Email(arg.Email)  // simple conversion
+	out.Label = out.Label         // types do not match
+	out.CreatedAt = out.CreatedAt // types do not match
+	out.Timeout = out.Timeout     // types do not match
+	return nil
+}
+
+func Convert_OrderInfos_Orders(args []*OrderInfo) (outs []*Order, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Order, len(args))
+	outs = make([]*Order, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_OrderInfo_Order(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Order_OrderInfo(arg *Order, out *OrderInfo) (*OrderInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &OrderInfo{}
+	}
+	if err := convert_Order_OrderInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Order_OrderInfo(arg *Order, out *OrderInfo) (err error) {
+	if out.ID, err = conversion.MarshalText[string](arg.ID, "OrderInfo.ID"); err != nil {
+		return err
+	}
+	out.Total = arg.Total.String() // text conversion
+	if out.Code, err = conversion.MarshalText[S](&arg.Code, "OrderInfo.Code"); err != nil {
+		return err
+	}
+	out.Email = string(arg.Email) // simple conversion
+	out.Label = out.Label         // types do not match
+	out.CreatedAt = out.CreatedAt // types do not match
+	out.Timeout = out.Timeout     // types do not match
+	return nil
+}
+
+func Convert_Orders_OrderInfos(args []*Order) (outs []*OrderInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]OrderInfo, len(args))
+	outs = make([]*OrderInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Order_OrderInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
@@ -0,0 +1,35 @@
+package times
+
+import "time"
+
+//go:generate go run github.com/olvrng/ggen-convert/cmd/ggen-convert github.com/olvrng/ggen-convert/tests/times
+
+// +gen:convert: github.com/olvrng/ggen-convert/tests/times; time=millis
+
+type Millis int64
+
+type Event struct {
+	ID        string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+	Timeout   time.Duration
+}
+
+// +convert:type=Event
+type EventRow struct {
+	ID        string
+	StartedAt int64
+	EndedAt   int64
+	CreatedAt string
+	Timeout   Millis
+}
+
+// +convert:type=Event
+type EventInfo struct {
+	ID        string
+	StartedAt string
+	EndedAt   string
+	CreatedAt *time.Time
+	Timeout   int64
+}
@@ -0,0 +1,77 @@
+package times
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertTimes(t *testing.T) {
+	startedAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+	event := &Event{
+		ID:        "1",
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		CreatedAt: startedAt,
+		Timeout:   5 * time.Second,
+	}
+
+	t.Run("Event to EventRow", func(t *testing.T) {
+		row, err := Convert_Event_EventRow(event, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &EventRow{
+			ID:        "1",
+			StartedAt: startedAt.UnixMilli(),
+			EndedAt:   endedAt.UnixMilli(),
+			CreatedAt: "2024-05-01T10:30:00Z",
+			Timeout:   5000,
+		}, row)
+
+		back, err := Convert_EventRow_Event(row, nil)
+		require.NoError(t, err)
+		assert.Equal(t, event, back)
+	})
+	t.Run("Event to EventInfo", func(t *testing.T) {
+		info, err := Convert_Event_EventInfo(event, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &EventInfo{
+			ID:        "1",
+			StartedAt: "2024-05-01T10:30:00Z",
+			EndedAt:   "2024-05-01T12:00:00Z",
+			CreatedAt: &startedAt,
+			Timeout:   5000,
+		}, info)
+
+		back, err := Convert_EventInfo_Event(info, nil)
+		require.NoError(t, err)
+		assert.Equal(t, event, back)
+	})
+	t.Run("zero values", func(t *testing.T) {
+		row, err := Convert_Event_EventRow(&Event{ID: "1"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &EventRow{ID: "1"}, row)
+
+		back, err := Convert_EventRow_Event(row, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &Event{ID: "1"}, back)
+	})
+	t.Run("invalid time", func(t *testing.T) {
+		_, err := Convert_EventInfo_Event(&EventInfo{StartedAt: "yesterday"}, nil)
+		assert.EqualError(t, err, `Event.StartedAt: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`)
+	})
+	t.Run("precision below milliseconds", func(t *testing.T) {
+		_, err := Convert_Event_EventRow(&Event{StartedAt: startedAt.Add(time.Microsecond)}, nil)
+		assert.EqualError(t, err, "EventRow.StartedAt: time 2024-05-01 10:30:00.000001 +0000 UTC can not be converted to milliseconds")
+
+		_, err = Convert_Event_EventRow(&Event{Timeout: 1500 * time.Microsecond}, nil)
+		assert.EqualError(t, err, "EventRow.Timeout: duration 1.5ms is not a multiple of 1ms")
+	})
+	t.Run("duration overflow", func(t *testing.T) {
+		_, err := Convert_EventRow_Event(&EventRow{Timeout: math.MaxInt64 / 10}, nil)
+		assert.EqualError(t, err, "Event.Timeout: value 922337203685477580 overflows time.Duration")
+	})
+}
@@ -0,0 +1,229 @@
+//go:build !generator
+// +build !generator
+
+// Code generated by generator convert. DO NOT EDIT.
+
+package times
+
+import (
+	time "time"
+
+	conversion "github.com/olvrng/ggen-convert/conversion"
+)
+
+/*
+Custom conversions: (none)
+
+Ignored functions: (none)
+*/
+
+func RegisterConversions(s *conversion.Scheme) {
+	registerConversions(s)
+}
+
+func registerConversions(s *conversion.Scheme) {
+	conversion.Register(s, func(arg *EventInfo, out *Event) error {
+		return conversion.CallInto(Convert_EventInfo_Event, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_EventInfos_Events)
+	conversion.RegisterValueSlice(s, func(arg *EventInfo, out *Event) error {
+		return conversion.CallInto(Convert_EventInfo_Event, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *EventInfo, out *Event) error {
+		return conversion.CallInto(Convert_EventInfo_Event, arg, out)
+	})
+	conversion.Register(s, func(arg *Event, out *EventInfo) error {
+		return conversion.CallInto(Convert_Event_EventInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Events_EventInfos)
+	conversion.RegisterValueSlice(s, func(arg *Event, out *EventInfo) error {
+		return conversion.CallInto(Convert_Event_EventInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Event, out *EventInfo) error {
+		return conversion.CallInto(Convert_Event_EventInfo, arg, out)
+	})
+	conversion.Register(s, func(arg *EventRow, out *Event) error {
+		return conversion.CallInto(Convert_EventRow_Event, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_EventRows_Events)
+	conversion.RegisterValueSlice(s, func(arg *EventRow, out *Event) error {
+		return conversion.CallInto(Convert_EventRow_Event, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *EventRow, out *Event) error {
+		return conversion.CallInto(Convert_EventRow_Event, arg, out)
+	})
+	conversion.Register(s, func(arg *Event, out *EventRow) error {
+		return conversion.CallInto(Convert_Event_EventRow, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Events_EventRows)
+	conversion.RegisterValueSlice(s, func(arg *Event, out *EventRow) error {
+		return conversion.CallInto(Convert_Event_EventRow, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Event, out *EventRow) error {
+		return conversion.CallInto(Convert_Event_EventRow, arg, out)
+	})
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests/times.Event --//
+
+func Convert_EventInfo_Event(arg *EventInfo, out *Event) (*Event, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Event{}
+	}
+	if err := convert_EventInfo_Event(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_EventInfo_Event(arg *EventInfo, out *Event) (err error) {
+	out.ID = arg.ID // simple assign
+	if out.StartedAt, err = conversion.StringToTime(arg.StartedAt, "Event.StartedAt"); err != nil {
+		return err
+	}
+	if out.EndedAt, err = conversion.StringToTimePtr(arg.EndedAt, "Event.EndedAt"); err != nil {
+		return err
+	}
+	out.CreatedAt = conversion.TimeValue(arg.CreatedAt) // time conversion
+	if out.Timeout, err = conversion.IntToDuration(arg.Timeout, time.Millisecond, "Event.Timeout"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_EventInfos_Events(args []*EventInfo) (outs []*Event, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Event, len(args))
+	outs = make([]*Event, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_EventInfo_Event(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Event_EventInfo(arg *Event, out *EventInfo) (*EventInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &EventInfo{}
+	}
+	if err := convert_Event_EventInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Event_EventInfo(arg *Event, out *EventInfo) (err error) {
+	out.ID = arg.ID                                                          // simple assign
+	out.StartedAt = conversion.TimeToString(arg.StartedAt)                   // time conversion
+	out.EndedAt = conversion.TimeToString(conversion.TimeValue(arg.EndedAt)) // time conversion
+	out.CreatedAt = conversion.TimePtr(arg.CreatedAt)                        // time conversion
+	if out.Timeout, err = conversion.DurationToInt[int64](arg.Timeout, time.Millisecond, "EventInfo.Timeout"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_Events_EventInfos(args []*Event) (outs []*EventInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]EventInfo, len(args))
+	outs = make([]*EventInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Event_EventInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_EventRow_Event(arg *EventRow, out *Event) (*Event, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Event{}
+	}
+	if err := convert_EventRow_Event(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_EventRow_Event(arg *EventRow, out *Event) (err error) {
+	out.ID = arg.ID                                                           // simple assign
+	out.StartedAt = conversion.UnixMilliToTime(arg.StartedAt)                 // time conversion
+	out.EndedAt = conversion.TimePtr(conversion.UnixMilliToTime(arg.EndedAt)) // time conversion
+	if out.CreatedAt, err = conversion.StringToTime(arg.CreatedAt, "Event.CreatedAt"); err != nil {
+		return err
+	}
+	if out.Timeout, err = conversion.IntToDuration(int64(arg.Timeout), time.Millisecond, "Event.Timeout"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_EventRows_Events(args []*EventRow) (outs []*Event, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Event, len(args))
+	outs = make([]*Event, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_EventRow_Event(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Event_EventRow(arg *Event, out *EventRow) (*EventRow, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &EventRow{}
+	}
+	if err := convert_Event_EventRow(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Event_EventRow(arg *Event, out *EventRow) (err error) {
+	out.ID = arg.ID // simple assign
+	if out.StartedAt, err = conversion.TimeToUnixMilli[int64](arg.StartedAt, "EventRow.StartedAt"); err != nil {
+		return err
+	}
+	if out.EndedAt, err = conversion.TimeToUnixMilli[int64](conversion.TimeValue(arg.EndedAt), "EventRow.EndedAt"); err != nil {
+		return err
+	}
+	out.CreatedAt = conversion.TimeToString(arg.CreatedAt) // time conversion
+	if out.Timeout, err = conversion.DurationToInt[Millis](arg.Timeout, time.Millisecond, "EventRow.Timeout"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_Events_EventRows(args []*Event) (outs []*EventRow, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]EventRow, len(args))
+	outs = make([]*EventRow, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Event_EventRow(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
@@ -1,3 +1,4 @@
+//go:build !generator
 // +build !generator
 
 // Code generated by generator convert. DO NOT EDIT.
@@ -5,6 +6,10 @@
 package tests
 
 import (
+	sql "database/sql"
+	fmt "fmt"
+	strconv "strconv"
+
 	conversion "github.com/olvrng/ggen-convert/conversion"
 )
 
@@ -22,385 +27,1417 @@ func RegisterConversions(s *conversion.Scheme) {
 }
 
 func registerConversions(s *conversion.Scheme) {
-	s.Register((*B)(nil), (*A)(nil), func(arg, out interface{}) error {
-		Convert_B_A(arg.(*B), out.(*A))
-		return nil
-	})
-	s.Register(([]*B)(nil), (*[]*A)(nil), func(arg, out interface{}) error {
-		out0 := Convert_BS_AS(arg.([]*B))
-		*out.(*[]*A) = out0
-		return nil
-	})
-	s.Register((*A)(nil), (*B)(nil), func(arg, out interface{}) error {
-		Convert_A_B(arg.(*A), out.(*B))
-		return nil
-	})
-	s.Register(([]*A)(nil), (*[]*B)(nil), func(arg, out interface{}) error {
-		out0 := Convert_AS_BS(arg.([]*A))
-		*out.(*[]*B) = out0
-		return nil
-	})
-	s.Register((*C1)(nil), (*C0)(nil), func(arg, out interface{}) error {
-		Convert_C1_C0(arg.(*C1), out.(*C0))
-		return nil
-	})
-	s.Register(([]*C1)(nil), (*[]*C0)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C1s_C0s(arg.([]*C1))
-		*out.(*[]*C0) = out0
-		return nil
-	})
-	s.Register((*C0)(nil), (*C1)(nil), func(arg, out interface{}) error {
-		Convert_C0_C1(arg.(*C0), out.(*C1))
-		return nil
-	})
-	s.Register(([]*C0)(nil), (*[]*C1)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C0s_C1s(arg.([]*C0))
-		*out.(*[]*C1) = out0
-		return nil
-	})
-	s.Register((*C2)(nil), (*C0)(nil), func(arg, out interface{}) error {
-		Convert_C2_C0(arg.(*C2), out.(*C0))
-		return nil
-	})
-	s.Register(([]*C2)(nil), (*[]*C0)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C2s_C0s(arg.([]*C2))
-		*out.(*[]*C0) = out0
-		return nil
-	})
-	s.Register((*C0)(nil), (*C2)(nil), func(arg, out interface{}) error {
-		Convert_C0_C2(arg.(*C0), out.(*C2))
-		return nil
-	})
-	s.Register(([]*C0)(nil), (*[]*C2)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C0s_C2s(arg.([]*C0))
-		*out.(*[]*C2) = out0
-		return nil
-	})
-	s.Register((*C3)(nil), (*C0)(nil), func(arg, out interface{}) error {
-		Convert_C3_C0(arg.(*C3), out.(*C0))
-		return nil
-	})
-	s.Register(([]*C3)(nil), (*[]*C0)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C3s_C0s(arg.([]*C3))
-		*out.(*[]*C0) = out0
-		return nil
-	})
-	s.Register((*C0)(nil), (*C3)(nil), func(arg, out interface{}) error {
-		Convert_C0_C3(arg.(*C0), out.(*C3))
-		return nil
-	})
-	s.Register(([]*C0)(nil), (*[]*C3)(nil), func(arg, out interface{}) error {
-		out0 := Convert_C0s_C3s(arg.([]*C0))
-		*out.(*[]*C3) = out0
-		return nil
-	})
-	s.Register((*D1)(nil), (*D0)(nil), func(arg, out interface{}) error {
-		Convert_D1_D0(arg.(*D1), out.(*D0))
-		return nil
-	})
-	s.Register(([]*D1)(nil), (*[]*D0)(nil), func(arg, out interface{}) error {
-		out0 := Convert_D1s_D0s(arg.([]*D1))
-		*out.(*[]*D0) = out0
-		return nil
-	})
-	s.Register((*D0)(nil), (*D1)(nil), func(arg, out interface{}) error {
-		Convert_D0_D1(arg.(*D0), out.(*D1))
-		return nil
-	})
-	s.Register(([]*D0)(nil), (*[]*D1)(nil), func(arg, out interface{}) error {
-		out0 := Convert_D0s_D1s(arg.([]*D0))
-		*out.(*[]*D1) = out0
-		return nil
+	conversion.Register(s, func(arg *B, out *A) error {
+		return conversion.CallInto(Convert_B_A, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_BS_AS)
+	conversion.RegisterValueSlice(s, func(arg *B, out *A) error {
+		return conversion.CallInto(Convert_B_A, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *B, out *A) error {
+		return conversion.CallInto(Convert_B_A, arg, out)
+	})
+	conversion.Register(s, func(arg *A, out *B) error {
+		return conversion.CallInto(Convert_A_B, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_AS_BS)
+	conversion.RegisterValueSlice(s, func(arg *A, out *B) error {
+		return conversion.CallInto(Convert_A_B, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *A, out *B) error {
+		return conversion.CallInto(Convert_A_B, arg, out)
+	})
+	conversion.Register(s, func(arg *C1, out *C0) error {
+		return conversion.CallInto(Convert_C1_C0, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C1s_C0s)
+	conversion.RegisterValueSlice(s, func(arg *C1, out *C0) error {
+		return conversion.CallInto(Convert_C1_C0, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C1, out *C0) error {
+		return conversion.CallInto(Convert_C1_C0, arg, out)
+	})
+	conversion.Register(s, func(arg *C0, out *C1) error {
+		return conversion.CallInto(Convert_C0_C1, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C0s_C1s)
+	conversion.RegisterValueSlice(s, func(arg *C0, out *C1) error {
+		return conversion.CallInto(Convert_C0_C1, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C0, out *C1) error {
+		return conversion.CallInto(Convert_C0_C1, arg, out)
+	})
+	conversion.Register(s, func(arg *C2, out *C0) error {
+		return conversion.CallInto(Convert_C2_C0, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C2s_C0s)
+	conversion.RegisterValueSlice(s, func(arg *C2, out *C0) error {
+		return conversion.CallInto(Convert_C2_C0, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C2, out *C0) error {
+		return conversion.CallInto(Convert_C2_C0, arg, out)
+	})
+	conversion.Register(s, func(arg *C0, out *C2) error {
+		return conversion.CallInto(Convert_C0_C2, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C0s_C2s)
+	conversion.RegisterValueSlice(s, func(arg *C0, out *C2) error {
+		return conversion.CallInto(Convert_C0_C2, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C0, out *C2) error {
+		return conversion.CallInto(Convert_C0_C2, arg, out)
+	})
+	conversion.Register(s, func(arg *C3, out *C0) error {
+		return conversion.CallInto(Convert_C3_C0, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C3s_C0s)
+	conversion.RegisterValueSlice(s, func(arg *C3, out *C0) error {
+		return conversion.CallInto(Convert_C3_C0, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C3, out *C0) error {
+		return conversion.CallInto(Convert_C3_C0, arg, out)
+	})
+	conversion.Register(s, func(arg *C0, out *C3) error {
+		return conversion.CallInto(Convert_C0_C3, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_C0s_C3s)
+	conversion.RegisterValueSlice(s, func(arg *C0, out *C3) error {
+		return conversion.CallInto(Convert_C0_C3, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *C0, out *C3) error {
+		return conversion.CallInto(Convert_C0_C3, arg, out)
+	})
+	conversion.Register(s, func(arg *CustomerInfo, out *Customer) error {
+		return conversion.CallInto(Convert_CustomerInfo_Customer, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_CustomerInfos_Customers)
+	conversion.RegisterValueSlice(s, func(arg *CustomerInfo, out *Customer) error {
+		return conversion.CallInto(Convert_CustomerInfo_Customer, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *CustomerInfo, out *Customer) error {
+		return conversion.CallInto(Convert_CustomerInfo_Customer, arg, out)
+	})
+	conversion.Register(s, func(arg *Customer, out *CustomerInfo) error {
+		return conversion.CallInto(Convert_Customer_CustomerInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Customers_CustomerInfos)
+	conversion.RegisterValueSlice(s, func(arg *Customer, out *CustomerInfo) error {
+		return conversion.CallInto(Convert_Customer_CustomerInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Customer, out *CustomerInfo) error {
+		return conversion.CallInto(Convert_Customer_CustomerInfo, arg, out)
+	})
+	conversion.Register(s, func(arg *D1, out *D0) error {
+		return conversion.CallInto(Convert_D1_D0, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_D1s_D0s)
+	conversion.RegisterValueSlice(s, func(arg *D1, out *D0) error {
+		return conversion.CallInto(Convert_D1_D0, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *D1, out *D0) error {
+		return conversion.CallInto(Convert_D1_D0, arg, out)
+	})
+	conversion.Register(s, func(arg *D0, out *D1) error {
+		return conversion.CallInto(Convert_D0_D1, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_D0s_D1s)
+	conversion.RegisterValueSlice(s, func(arg *D0, out *D1) error {
+		return conversion.CallInto(Convert_D0_D1, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *D0, out *D1) error {
+		return conversion.CallInto(Convert_D0_D1, arg, out)
+	})
+	conversion.Register(s, func(arg *ImageInfo, out *Image) error {
+		return conversion.CallInto(Convert_ImageInfo_Image, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_ImageInfos_Images)
+	conversion.RegisterValueSlice(s, func(arg *ImageInfo, out *Image) error {
+		return conversion.CallInto(Convert_ImageInfo_Image, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *ImageInfo, out *Image) error {
+		return conversion.CallInto(Convert_ImageInfo_Image, arg, out)
+	})
+	conversion.Register(s, func(arg *Image, out *ImageInfo) error {
+		return conversion.CallInto(Convert_Image_ImageInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Images_ImageInfos)
+	conversion.RegisterValueSlice(s, func(arg *Image, out *ImageInfo) error {
+		return conversion.CallInto(Convert_Image_ImageInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Image, out *ImageInfo) error {
+		return conversion.CallInto(Convert_Image_ImageInfo, arg, out)
+	})
+	conversion.Register(s, func(arg *ImageRow, out *Image) error {
+		return conversion.CallInto(Convert_ImageRow_Image, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_ImageRows_Images)
+	conversion.RegisterValueSlice(s, func(arg *ImageRow, out *Image) error {
+		return conversion.CallInto(Convert_ImageRow_Image, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *ImageRow, out *Image) error {
+		return conversion.CallInto(Convert_ImageRow_Image, arg, out)
+	})
+	conversion.Register(s, func(arg *Image, out *ImageRow) error {
+		return conversion.CallInto(Convert_Image_ImageRow, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Images_ImageRows)
+	conversion.RegisterValueSlice(s, func(arg *Image, out *ImageRow) error {
+		return conversion.CallInto(Convert_Image_ImageRow, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Image, out *ImageRow) error {
+		return conversion.CallInto(Convert_Image_ImageRow, arg, out)
+	})
+	conversion.Register(s, func(arg *JobRow, out *Job) error {
+		return conversion.CallInto(Convert_JobRow_Job, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_JobRows_Jobs)
+	conversion.RegisterValueSlice(s, func(arg *JobRow, out *Job) error {
+		return conversion.CallInto(Convert_JobRow_Job, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *JobRow, out *Job) error {
+		return conversion.CallInto(Convert_JobRow_Job, arg, out)
+	})
+	conversion.Register(s, func(arg *Job, out *JobRow) error {
+		return conversion.CallInto(Convert_Job_JobRow, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Jobs_JobRows)
+	conversion.RegisterValueSlice(s, func(arg *Job, out *JobRow) error {
+		return conversion.CallInto(Convert_Job_JobRow, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Job, out *JobRow) error {
+		return conversion.CallInto(Convert_Job_JobRow, arg, out)
+	})
+	conversion.Register(s, func(arg *MetricsRow, out *Metrics) error {
+		return conversion.CallInto(Convert_MetricsRow_Metrics, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_MetricsRows_Metrics)
+	conversion.RegisterValueSlice(s, func(arg *MetricsRow, out *Metrics) error {
+		return conversion.CallInto(Convert_MetricsRow_Metrics, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *MetricsRow, out *Metrics) error {
+		return conversion.CallInto(Convert_MetricsRow_Metrics, arg, out)
+	})
+	conversion.Register(s, func(arg *Metrics, out *MetricsRow) error {
+		return conversion.CallInto(Convert_Metrics_MetricsRow, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Metrics_MetricsRows)
+	conversion.RegisterValueSlice(s, func(arg *Metrics, out *MetricsRow) error {
+		return conversion.CallInto(Convert_Metrics_MetricsRow, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Metrics, out *MetricsRow) error {
+		return conversion.CallInto(Convert_Metrics_MetricsRow, arg, out)
+	})
+	conversion.Register(s, func(arg *PersonInfo, out *Person) error {
+		return conversion.CallInto(Convert_PersonInfo_Person, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_PersonInfos_People)
+	conversion.RegisterValueSlice(s, func(arg *PersonInfo, out *Person) error {
+		return conversion.CallInto(Convert_PersonInfo_Person, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *PersonInfo, out *Person) error {
+		return conversion.CallInto(Convert_PersonInfo_Person, arg, out)
+	})
+	conversion.Register(s, func(arg *Person, out *PersonInfo) error {
+		return conversion.CallInto(Convert_Person_PersonInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_People_PersonInfos)
+	conversion.RegisterValueSlice(s, func(arg *Person, out *PersonInfo) error {
+		return conversion.CallInto(Convert_Person_PersonInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Person, out *PersonInfo) error {
+		return conversion.CallInto(Convert_Person_PersonInfo, arg, out)
+	})
+	conversion.Register(s, func(arg *UpdatePersonArgs, out *Person) error {
+		return conversion.CallInto(Apply_UpdatePersonArgs_Person, arg, out)
+	})
+	conversion.Register(s, func(arg *ProfileMessage, out *Profile) error {
+		return conversion.CallInto(Convert_ProfileMessage_Profile, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_ProfileMessages_Profiles)
+	conversion.RegisterValueSlice(s, func(arg *ProfileMessage, out *Profile) error {
+		return conversion.CallInto(Convert_ProfileMessage_Profile, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *ProfileMessage, out *Profile) error {
+		return conversion.CallInto(Convert_ProfileMessage_Profile, arg, out)
+	})
+	conversion.Register(s, func(arg *Profile, out *ProfileMessage) error {
+		return conversion.CallInto(Convert_Profile_ProfileMessage, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Profiles_ProfileMessages)
+	conversion.RegisterValueSlice(s, func(arg *Profile, out *ProfileMessage) error {
+		return conversion.CallInto(Convert_Profile_ProfileMessage, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *Profile, out *ProfileMessage) error {
+		return conversion.CallInto(Convert_Profile_ProfileMessage, arg, out)
+	})
+	conversion.Register(s, func(arg *CreateUserArgs, out *User) error {
+		return conversion.CallInto(Apply_CreateUserArgs_User, arg, out)
+	})
+	conversion.Register(s, func(arg *UpdateUserArgs, out *User) error {
+		return conversion.CallInto(Apply_UpdateUserArgs_User, arg, out)
+	})
+	conversion.Register(s, func(arg *UserInfo, out *User) error {
+		return conversion.CallInto(Convert_UserInfo_User, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_UserInfos_Users)
+	conversion.RegisterValueSlice(s, func(arg *UserInfo, out *User) error {
+		return conversion.CallInto(Convert_UserInfo_User, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *UserInfo, out *User) error {
+		return conversion.CallInto(Convert_UserInfo_User, arg, out)
+	})
+	conversion.Register(s, func(arg *User, out *UserInfo) error {
+		return conversion.CallInto(Convert_User_UserInfo, arg, out)
+	})
+	conversion.RegisterSlice(s, Convert_Users_UserInfos)
+	conversion.RegisterValueSlice(s, func(arg *User, out *UserInfo) error {
+		return conversion.CallInto(Convert_User_UserInfo, arg, out)
+	})
+	conversion.RegisterMap(s, func(arg *User, out *UserInfo) error {
+		return conversion.CallInto(Convert_User_UserInfo, arg, out)
 	})
 }
 
 //-- convert github.com/olvrng/ggen-convert/tests.A --//
 
-func Convert_B_A(arg *B, out *A) *A {
+func Convert_B_A(arg *B, out *A) (*A, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &A{}
 	}
-	convert_B_A(arg, out)
-	return out
+	if err := convert_B_A(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_B_A(arg *B, out *A) {
-	out.Value = out.Value           // types do not match
+func convert_B_A(arg *B, out *A) (err error) {
+	if out.Value, err = conversion.ParseInt[int](arg.Value, 0, "A.Value"); err != nil {
+		return err
+	}
 	out.Int = int64(arg.Int)        // simple conversion
 	out.String = string(arg.String) // simple conversion
 	out.Strings = arg.Strings       // simple assign
-	out.C = Convert_C1_C0(arg.C, nil)
-	out.Cs = Convert_C1s_C0s(arg.Cs)
-	out.D = Convert_D1_D0(arg.D, nil)
-	out.Ds = Convert_D1s_D0s(arg.Ds)
-	out.E = arg.E     // simple assign
-	out.Ep = arg.Ep   // simple assign
-	out.Es = arg.Es   // simple assign
-	out.Eps = arg.Eps // simple assign
+	if out.C, err = Convert_C1_C0(arg.C, nil); err != nil {
+		return err
+	}
+	if out.Cs, err = Convert_C1s_C0s(arg.Cs); err != nil {
+		return err
+	}
+	if out.D, err = Convert_D1_D0(arg.D, nil); err != nil {
+		return err
+	}
+	if out.Ds, err = Convert_D1s_D0s(arg.Ds); err != nil {
+		return err
+	}
+	out.E = arg.E           // simple assign
+	out.Ep = arg.Ep         // simple assign
+	out.Es = arg.Es         // simple assign
+	out.Eps = arg.Eps       // simple assign
+	out.Labels = arg.Labels // simple assign
+	if out.CMap, err = conversion.ConvertMap(arg.CMap, conversion.Identity[string], Convert_C1_C0); err != nil {
+		return err
+	}
+	if out.CValues, err = conversion.ConvertMapValue(arg.CValues, func(k S) string { return string(k) }, Convert_C1_C0); err != nil {
+		return err
+	}
+	out.Keys = conversion.ConvertMapKeys(arg.Keys, func(k string) S { return S(k) }) // convert map keys
+	if out.Cv, err = conversion.ConvertValue(arg.Cv, Convert_C1_C0); err != nil {
+		return err
+	}
+	if out.Cvs, err = conversion.ConvertSliceValue(arg.Cvs, Convert_C1_C0); err != nil {
+		return err
+	}
+	if out.Cp, err = conversion.ConvertValueToPointer(arg.Cp, Convert_C1_C0); err != nil {
+		return err
+	}
+	if out.Cq, err = conversion.ConvertPointerToValue(arg.Cq, Convert_C1_C0); err != nil {
+		return err
+	}
+	return nil
 }
 
-func Convert_BS_AS(args []*B) (outs []*A) {
+func Convert_BS_AS(args []*B) (outs []*A, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]A, len(args))
 	outs = make([]*A, len(args))
 	for i := range tmps {
-		outs[i] = Convert_B_A(args[i], &tmps[i])
+		if outs[i], err = Convert_B_A(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_A_B(arg *A, out *B) *B {
+func Convert_A_B(arg *A, out *B) (*B, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &B{}
 	}
-	ConvertAB(arg, out)
-	return out
+	if err := ConvertAB(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_A_B(arg *A, out *B) {
-	out.Value = out.Value      // types do not match
-	out.Int = int32(arg.Int)   // simple conversion
-	out.String = S(arg.String) // simple conversion
-	out.Strings = arg.Strings  // simple assign
-	out.C = Convert_C0_C1(arg.C, nil)
-	out.Cs = Convert_C0s_C1s(arg.Cs)
-	out.D = Convert_D0_D1(arg.D, nil)
-	out.Ds = Convert_D0s_D1s(arg.Ds)
-	out.E = arg.E     // simple assign
-	out.Ep = arg.Ep   // simple assign
-	out.Es = arg.Es   // simple assign
-	out.Eps = arg.Eps // simple assign
+func convert_A_B(arg *A, out *B) (err error) {
+	out.Value = strconv.FormatInt(int64(arg.Value), 10) // strconv conversion
+	out.Int = int32(arg.Int)                            // simple conversion
+	out.String = S(arg.String)                          // simple conversion
+	out.Strings = arg.Strings                           // simple assign
+	if out.C, err = Convert_C0_C1(arg.C, nil); err != nil {
+		return err
+	}
+	if out.Cs, err = Convert_C0s_C1s(arg.Cs); err != nil {
+		return err
+	}
+	if out.D, err = Convert_D0_D1(arg.D, nil); err != nil {
+		return err
+	}
+	if out.Ds, err = Convert_D0s_D1s(arg.Ds); err != nil {
+		return err
+	}
+	out.E = arg.E           // simple assign
+	out.Ep = arg.Ep         // simple assign
+	out.Es = arg.Es         // simple assign
+	out.Eps = arg.Eps       // simple assign
+	out.Labels = arg.Labels // simple assign
+	if out.CMap, err = conversion.ConvertMap(arg.CMap, conversion.Identity[string], Convert_C0_C1); err != nil {
+		return err
+	}
+	if out.CValues, err = conversion.ConvertMapValue(arg.CValues, func(k string) S { return S(k) }, Convert_C0_C1); err != nil {
+		return err
+	}
+	out.Keys = conversion.ConvertMapKeys(arg.Keys, func(k S) string { return string(k) }) // convert map keys
+	if out.Cv, err = conversion.ConvertValue(arg.Cv, Convert_C0_C1); err != nil {
+		return err
+	}
+	if out.Cvs, err = conversion.ConvertSliceValue(arg.Cvs, Convert_C0_C1); err != nil {
+		return err
+	}
+	if out.Cp, err = conversion.ConvertPointerToValue(arg.Cp, Convert_C0_C1); err != nil {
+		return err
+	}
+	if out.Cq, err = conversion.ConvertValueToPointer(arg.Cq, Convert_C0_C1); err != nil {
+		return err
+	}
+	return nil
 }
 
-func Convert_AS_BS(args []*A) (outs []*B) {
+func Convert_AS_BS(args []*A) (outs []*B, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]B, len(args))
 	outs = make([]*B, len(args))
 	for i := range tmps {
-		outs[i] = Convert_A_B(args[i], &tmps[i])
+		if outs[i], err = Convert_A_B(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
 //-- convert github.com/olvrng/ggen-convert/tests.C0 --//
 
-func Convert_C1_C0(arg *C1, out *C0) *C0 {
-	return ConvertC10(arg, out)
+func Convert_C1_C0(arg *C1, out *C0) (*C0, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &C0{}
+	}
+	if err := ConvertC10(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_C1_C0(arg *C1, out *C0) {
-	out.Value = out.Value // types do not match
+func convert_C1_C0(arg *C1, out *C0) (err error) {
+	if out.Value, err = conversion.ParseInt[int](arg.Value, 0, "C0.Value"); err != nil {
+		return err
+	}
+	return nil
 }
 
-func Convert_C1s_C0s(args []*C1) (outs []*C0) {
+func Convert_C1s_C0s(args []*C1) (outs []*C0, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C0, len(args))
 	outs = make([]*C0, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C1_C0(args[i], &tmps[i])
+		if outs[i], err = Convert_C1_C0(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_C0_C1(arg *C0, out *C1) *C1 {
+func Convert_C0_C1(arg *C0, out *C1) (*C1, error) {
 	return ConvertC01(arg)
 }
 
-func convert_C0_C1(arg *C0, out *C1) {
-	out.Value = out.Value // types do not match
+func convert_C0_C1(arg *C0, out *C1) (err error) {
+	out.Value = strconv.FormatInt(int64(arg.Value), 10) // strconv conversion
+	return nil
 }
 
-func Convert_C0s_C1s(args []*C0) (outs []*C1) {
+func Convert_C0s_C1s(args []*C0) (outs []*C1, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C1, len(args))
 	outs = make([]*C1, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C0_C1(args[i], &tmps[i])
+		if outs[i], err = Convert_C0_C1(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_C2_C0(arg *C2, out *C0) *C0 {
+func Convert_C2_C0(arg *C2, out *C0) (*C0, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &C0{}
 	}
-	convert_C2_C0(arg, out)
-	return out
+	if err := convert_C2_C0(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_C2_C0(arg *C2, out *C0) {
+func convert_C2_C0(arg *C2, out *C0) (err error) {
 	*out = arg.C0 // embedded struct
+	return nil
 }
 
-func Convert_C2s_C0s(args []*C2) (outs []*C0) {
+func Convert_C2s_C0s(args []*C2) (outs []*C0, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C0, len(args))
 	outs = make([]*C0, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C2_C0(args[i], &tmps[i])
+		if outs[i], err = Convert_C2_C0(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_C0_C2(arg *C0, out *C2) *C2 {
+func Convert_C0_C2(arg *C0, out *C2) (*C2, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &C2{}
 	}
-	convert_C0_C2(arg, out)
-	return out
+	if err := convert_C0_C2(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_C0_C2(arg *C0, out *C2) {
+func convert_C0_C2(arg *C0, out *C2) (err error) {
 	out.C0 = *arg // embedded struct
 	out.X = out.X // no change
 	out.Y = out.Y // no change
 	out.Z = out.Z // no change
+	return nil
 }
 
-func Convert_C0s_C2s(args []*C0) (outs []*C2) {
+func Convert_C0s_C2s(args []*C0) (outs []*C2, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C2, len(args))
 	outs = make([]*C2, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C0_C2(args[i], &tmps[i])
+		if outs[i], err = Convert_C0_C2(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_C3_C0(arg *C3, out *C0) *C0 {
+func Convert_C3_C0(arg *C3, out *C0) (*C0, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &C0{}
 	}
-	convert_C3_C0(arg, out)
-	return out
+	if err := convert_C3_C0(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_C3_C0(arg *C3, out *C0) {
+func convert_C3_C0(arg *C3, out *C0) (err error) {
 	*out = *arg.C0 // embedded struct
+	return nil
 }
 
-func Convert_C3s_C0s(args []*C3) (outs []*C0) {
+func Convert_C3s_C0s(args []*C3) (outs []*C0, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C0, len(args))
 	outs = make([]*C0, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C3_C0(args[i], &tmps[i])
+		if outs[i], err = Convert_C3_C0(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_C0_C3(arg *C0, out *C3) *C3 {
+func Convert_C0_C3(arg *C0, out *C3) (*C3, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &C3{}
 	}
-	convert_C0_C3(arg, out)
-	return out
+	if err := convert_C0_C3(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_C0_C3(arg *C0, out *C3) {
+func convert_C0_C3(arg *C0, out *C3) (err error) {
 	out.C0 = new(C0) // embedded struct
 	*out.C0 = *arg   // embedded struct
 	out.X = out.X    // no change
 	out.Y = out.Y    // no change
 	out.Z = out.Z    // no change
+	return nil
 }
 
-func Convert_C0s_C3s(args []*C0) (outs []*C3) {
+func Convert_C0s_C3s(args []*C0) (outs []*C3, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]C3, len(args))
 	outs = make([]*C3, len(args))
 	for i := range tmps {
-		outs[i] = Convert_C0_C3(args[i], &tmps[i])
+		if outs[i], err = Convert_C0_C3(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Customer --//
+
+func Convert_CustomerInfo_Customer(arg *CustomerInfo, out *Customer) (*Customer, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Customer{}
+	}
+	if err := convert_CustomerInfo_Customer(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_CustomerInfo_Customer(arg *CustomerInfo, out *Customer) (err error) {
+	out.Name = sql.NullString{String: conversion.PointerValue(arg.Name), Valid: arg.Name != nil}         // null conversion
+	out.Age = sql.NullInt64{Int64: int64(arg.Age), Valid: true}                                          // null conversion
+	out.Verified = sql.NullBool{Bool: conversion.PointerValue(arg.Verified), Valid: arg.Verified != nil} // null conversion
+	out.JoinedAt = sql.NullTime{Time: conversion.PointerValue(arg.JoinedAt), Valid: arg.JoinedAt != nil} // null conversion
+	out.Score = sql.NullFloat64{Float64: arg.Score, Valid: true}                                         // null conversion
+	out.Nickname = NullS{S: S(conversion.PointerValue(arg.Nickname)), Valid: arg.Nickname != nil}        // null conversion
+	return nil
+}
+
+func Convert_CustomerInfos_Customers(args []*CustomerInfo) (outs []*Customer, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Customer, len(args))
+	outs = make([]*Customer, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_CustomerInfo_Customer(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Customer_CustomerInfo(arg *Customer, out *CustomerInfo) (*CustomerInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &CustomerInfo{}
+	}
+	if err := convert_Customer_CustomerInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Customer_CustomerInfo(arg *Customer, out *CustomerInfo) (err error) {
+	out.Name = conversion.NullPointer(arg.Name.String, arg.Name.Valid)                                                                          // null conversion
+	out.Age = int32(conversion.NullValue(arg.Age.Int64, arg.Age.Valid))                                                                         // null conversion
+	out.Verified = conversion.NullPointer(arg.Verified.Bool, arg.Verified.Valid)                                                                // null conversion
+	out.JoinedAt = conversion.NullPointer(arg.JoinedAt.Time, arg.JoinedAt.Valid)                                                                // null conversion
+	out.Score = conversion.NullValue(arg.Score.Float64, arg.Score.Valid)                                                                        // null conversion
+	out.Nickname = conversion.ConvertPointer(conversion.NullPointer(arg.Nickname.S, arg.Nickname.Valid), func(v S) string { return string(v) }) // null conversion
+	return nil
+}
+
+func Convert_Customers_CustomerInfos(args []*Customer) (outs []*CustomerInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]CustomerInfo, len(args))
+	outs = make([]*CustomerInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Customer_CustomerInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
 }
 
 //-- convert github.com/olvrng/ggen-convert/tests.D0 --//
 
-func Convert_D1_D0(arg *D1, out *D0) *D0 {
+func Convert_D1_D0(arg *D1, out *D0) (*D0, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &D0{}
 	}
-	convert_D1_D0(arg, out)
-	return out
+	if err := convert_D1_D0(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_D1_D0(arg *D1, out *D0) {
+func convert_D1_D0(arg *D1, out *D0) (err error) {
 	out.Value = arg.Value // simple assign
+	return nil
 }
 
-func Convert_D1s_D0s(args []*D1) (outs []*D0) {
+func Convert_D1s_D0s(args []*D1) (outs []*D0, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]D0, len(args))
 	outs = make([]*D0, len(args))
 	for i := range tmps {
-		outs[i] = Convert_D1_D0(args[i], &tmps[i])
+		if outs[i], err = Convert_D1_D0(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
 	}
-	return outs
+	return outs, nil
 }
 
-func Convert_D0_D1(arg *D0, out *D1) *D1 {
+func Convert_D0_D1(arg *D0, out *D1) (*D1, error) {
 	if arg == nil {
-		return nil
+		return nil, nil
 	}
 	if out == nil {
 		out = &D1{}
 	}
-	convert_D0_D1(arg, out)
-	return out
+	if err := convert_D0_D1(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func convert_D0_D1(arg *D0, out *D1) {
+func convert_D0_D1(arg *D0, out *D1) (err error) {
 	out.Value = arg.Value // simple assign
+	return nil
 }
 
-func Convert_D0s_D1s(args []*D0) (outs []*D1) {
+func Convert_D0s_D1s(args []*D0) (outs []*D1, err error) {
 	if args == nil {
-		return nil
+		return nil, nil
 	}
 	tmps := make([]D1, len(args))
 	outs = make([]*D1, len(args))
 	for i := range tmps {
-		outs[i] = Convert_D0_D1(args[i], &tmps[i])
+		if outs[i], err = Convert_D0_D1(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Image --//
+
+func Convert_ImageInfo_Image(arg *ImageInfo, out *Image) (*Image, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Image{}
+	}
+	if err := convert_ImageInfo_Image(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_ImageInfo_Image(arg *ImageInfo, out *Image) (err error) {
+	out.ImageID = arg.ImageId   // simple assign
+	out.ImageURL = arg.ImageUrl // simple assign
+	out.AltText = arg.AltText   // simple assign
+	return nil
+}
+
+func Convert_ImageInfos_Images(args []*ImageInfo) (outs []*Image, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Image, len(args))
+	outs = make([]*Image, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_ImageInfo_Image(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Image_ImageInfo(arg *Image, out *ImageInfo) (*ImageInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &ImageInfo{}
+	}
+	if err := convert_Image_ImageInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Image_ImageInfo(arg *Image, out *ImageInfo) (err error) {
+	out.ImageId = arg.ImageID   // simple assign
+	out.ImageUrl = arg.ImageURL // simple assign
+	out.AltText = arg.AltText   // simple assign
+	return nil
+}
+
+func Convert_Images_ImageInfos(args []*Image) (outs []*ImageInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]ImageInfo, len(args))
+	outs = make([]*ImageInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Image_ImageInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_ImageRow_Image(arg *ImageRow, out *Image) (*Image, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Image{}
+	}
+	if err := convert_ImageRow_Image(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_ImageRow_Image(arg *ImageRow, out *Image) (err error) {
+	out.ImageID = arg.Image_ID   // simple assign
+	out.ImageURL = arg.Image_URL // simple assign
+	out.AltText = arg.Alt_Text   // simple assign
+	return nil
+}
+
+func Convert_ImageRows_Images(args []*ImageRow) (outs []*Image, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Image, len(args))
+	outs = make([]*Image, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_ImageRow_Image(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Image_ImageRow(arg *Image, out *ImageRow) (*ImageRow, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &ImageRow{}
+	}
+	if err := convert_Image_ImageRow(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Image_ImageRow(arg *Image, out *ImageRow) (err error) {
+	out.Image_ID = arg.ImageID   // simple assign
+	out.Image_URL = arg.ImageURL // simple assign
+	out.Alt_Text = arg.AltText   // simple assign
+	return nil
+}
+
+func Convert_Images_ImageRows(args []*Image) (outs []*ImageRow, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]ImageRow, len(args))
+	outs = make([]*ImageRow, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Image_ImageRow(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Job --//
+
+func Convert_JobRow_Job(arg *JobRow, out *Job) (*Job, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Job{}
+	}
+	if err := convert_JobRow_Job(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_JobRow_Job(arg *JobRow, out *Job) (err error) {
+	out.Name = arg.Name // simple assign
+	if out.Status, err = convert_StatusCode_Status(arg.Status); err != nil {
+		return err
+	}
+	out.Budget = Cents(arg.Budget) // simple conversion
+	return nil
+}
+
+func Convert_JobRows_Jobs(args []*JobRow) (outs []*Job, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Job, len(args))
+	outs = make([]*Job, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_JobRow_Job(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Job_JobRow(arg *Job, out *JobRow) (*JobRow, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &JobRow{}
+	}
+	if err := convert_Job_JobRow(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Job_JobRow(arg *Job, out *JobRow) (err error) {
+	out.Name = arg.Name // simple assign
+	if out.Status, err = convert_Status_StatusCode(arg.Status); err != nil {
+		return err
+	}
+	out.Budget = Amount(arg.Budget) // simple conversion
+	return nil
+}
+
+func Convert_Jobs_JobRows(args []*Job) (outs []*JobRow, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]JobRow, len(args))
+	outs = make([]*JobRow, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Job_JobRow(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Metrics --//
+
+func Convert_MetricsRow_Metrics(arg *MetricsRow, out *Metrics) (*Metrics, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Metrics{}
+	}
+	if err := convert_MetricsRow_Metrics(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_MetricsRow_Metrics(arg *MetricsRow, out *Metrics) (err error) {
+	out.Count = int64(arg.Count)   // simple conversion
+	out.Ratio = float64(arg.Ratio) // simple conversion
+	out.Score = float64(arg.Score) // simple conversion
+	if out.Size, err = conversion.CheckedInt[uint32](arg.Size, "Metrics.Size"); err != nil {
+		return err
+	}
+	if out.Visits, err = conversion.CheckedInt[int32](arg.Visits, "Metrics.Visits"); err != nil {
+		return err
+	}
+	out.Hits = conversion.Pointer(int64(arg.Hits))                                                  // pointer conversion
+	out.Misses = sql.NullInt64{Int64: int64(arg.Misses), Valid: true}                               // null conversion
+	out.Peak = conversion.ConvertPointer(arg.Peak, func(v int16) int64 { return int64(v) })         // simple conversion
+	out.Level = int64(conversion.NullValue(arg.Level.Int16, arg.Level.Valid))                       // null conversion
+	out.Errors = conversion.ConvertPointer(arg.Errors, func(v int32) float64 { return float64(v) }) // simple conversion
+	out.Limit = int64(conversion.PointerValue(arg.Limit))                                           // pointer conversion
+	return nil
+}
+
+func Convert_MetricsRows_Metrics(args []*MetricsRow) (outs []*Metrics, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Metrics, len(args))
+	outs = make([]*Metrics, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_MetricsRow_Metrics(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Metrics_MetricsRow(arg *Metrics, out *MetricsRow) (*MetricsRow, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &MetricsRow{}
+	}
+	if err := convert_Metrics_MetricsRow(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Metrics_MetricsRow(arg *Metrics, out *MetricsRow) (err error) {
+	if out.Count, err = conversion.CheckedInt[int32](arg.Count, "MetricsRow.Count"); err != nil {
+		return err
+	}
+	if out.Ratio, err = conversion.CheckedFloat[float32](arg.Ratio, "MetricsRow.Ratio"); err != nil {
+		return err
+	}
+	if out.Score, err = conversion.CheckedFloatToInt[int32](arg.Score, "MetricsRow.Score"); err != nil {
+		return err
+	}
+	if out.Size, err = conversion.CheckedInt[int16](arg.Size, "MetricsRow.Size"); err != nil {
+		return err
+	}
+	out.Visits = int64(arg.Visits) // simple conversion
+	if out.Hits, err = conversion.CheckedInt[int32](conversion.PointerValue(arg.Hits), "MetricsRow.Hits"); err != nil {
+		return err
+	}
+	if out.Misses, err = conversion.CheckedInt[int32](conversion.NullValue(arg.Misses.Int64, arg.Misses.Valid), "MetricsRow.Misses"); err != nil {
+		return err
+	}
+	if out.Peak, err = conversion.ConvertPointerErr(arg.Peak, func(v int64) (int16, error) { return conversion.CheckedInt[int16](v, "MetricsRow.Peak") }); err != nil {
+		return err
+	}
+	if out.Level, err = conversion.ConvertNull(arg.Level, true, func(v int64) (int16, error) { return conversion.CheckedInt[int16](v, "MetricsRow.Level") }, func(v int16, valid bool) sql.NullInt16 { return sql.NullInt16{Int16: v, Valid: valid} }); err != nil {
+		return err
+	}
+	if out.Errors, err = conversion.ConvertPointerErr(arg.Errors, func(v float64) (int32, error) { return conversion.CheckedFloatToInt[int32](v, "MetricsRow.Errors") }); err != nil {
+		return err
+	}
+	if out.Limit, err = conversion.ConvertPointerErr(&arg.Limit, func(v int64) (int8, error) { return conversion.CheckedInt[int8](v, "MetricsRow.Limit") }); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_Metrics_MetricsRows(args []*Metrics) (outs []*MetricsRow, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]MetricsRow, len(args))
+	outs = make([]*MetricsRow, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Metrics_MetricsRow(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Person --//
+
+func Convert_PersonInfo_Person(arg *PersonInfo, out *Person) (*Person, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Person{}
+	}
+	if err := convert_PersonInfo_Person(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_PersonInfo_Person(arg *PersonInfo, out *Person) (err error) {
+	out.Name = arg.Name // simple assign
+	if arg.City != "" {
+		if out.Address == nil {
+			out.Address = new(Address)
+		}
+	}
+	if out.Address != nil {
+		out.Address.City = arg.City // simple assign
+	}
+	if arg.AddressStreet != "" {
+		if out.Address == nil {
+			out.Address = new(Address)
+		}
+	}
+	if out.Address != nil {
+		out.Address.Street = arg.AddressStreet // simple assign
+	}
+	out.Home.City = arg.HomeCity // simple assign
+	out.Home.Zip = arg.HomeZip   // simple assign
+	return nil
+}
+
+func Convert_PersonInfos_People(args []*PersonInfo) (outs []*Person, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Person, len(args))
+	outs = make([]*Person, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_PersonInfo_Person(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Person_PersonInfo(arg *Person, out *PersonInfo) (*PersonInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &PersonInfo{}
+	}
+	if err := convert_Person_PersonInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Person_PersonInfo(arg *Person, out *PersonInfo) (err error) {
+	out.Name = arg.Name // simple assign
+	if arg.Address != nil {
+		out.City = arg.Address.City // simple assign
+	} else {
+		out.City = ""
+	}
+	if arg.Address != nil {
+		out.AddressStreet = arg.Address.Street // simple assign
+	} else {
+		out.AddressStreet = ""
+	}
+	out.HomeCity = arg.Home.City // simple assign
+	out.HomeZip = arg.Home.Zip   // simple assign
+	return nil
+}
+
+func Convert_People_PersonInfos(args []*Person) (outs []*PersonInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]PersonInfo, len(args))
+	outs = make([]*PersonInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Person_PersonInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Apply_UpdatePersonArgs_Person(arg *UpdatePersonArgs, out *Person) (*Person, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Person{}
+	}
+	if err := apply_UpdatePersonArgs_Person(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func apply_UpdatePersonArgs_Person(arg *UpdatePersonArgs, out *Person) (err error) {
+	out.Name = out.Name // identifier
+	if arg.City != (NullString{}) {
+		if out.Address == nil {
+			out.Address = new(Address)
+		}
+	}
+	if out.Address != nil {
+		out.Address.City = arg.City.Apply(out.Address.City) // apply change
+	}
+	out.Home.City = conversion.PointerValueOr(arg.HomeCity, out.Home.City) // pointer conversion
+	if out.Home.Zip, err = conversion.ParseInt[int](arg.HomeZip, 0, "Person.Home.Zip"); err != nil {
+		return err
+	}
+	return nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.Profile --//
+
+func Convert_ProfileMessage_Profile(arg *ProfileMessage, out *Profile) (*Profile, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &Profile{}
+	}
+	if err := convert_ProfileMessage_Profile(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_ProfileMessage_Profile(arg *ProfileMessage, out *Profile) (err error) {
+	out.Name = conversion.PointerValue(arg.Name)          // pointer conversion
+	out.Age = int32(conversion.PointerValue(arg.Age))     // pointer conversion
+	out.Score = conversion.PointerValue(arg.Score)        // pointer conversion
+	out.Country = S(conversion.PointerValue(arg.Country)) // pointer conversion
+	out.Bio = conversion.PointerValueOr(arg.Bio, out.Bio) // pointer conversion
+	if out.Email, err = conversion.RequirePointerValue(arg.Email, "Profile.Email"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_ProfileMessages_Profiles(args []*ProfileMessage) (outs []*Profile, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]Profile, len(args))
+	outs = make([]*Profile, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_ProfileMessage_Profile(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_Profile_ProfileMessage(arg *Profile, out *ProfileMessage) (*ProfileMessage, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &ProfileMessage{}
+	}
+	if err := convert_Profile_ProfileMessage(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_Profile_ProfileMessage(arg *Profile, out *ProfileMessage) (err error) {
+	out.Name = conversion.Pointer(arg.Name)               // pointer conversion
+	out.Age = conversion.Pointer(int64(arg.Age))          // pointer conversion
+	out.Score = conversion.Pointer(arg.Score)             // pointer conversion
+	out.Country = conversion.Pointer(string(arg.Country)) // pointer conversion
+	out.Bio = conversion.Pointer(arg.Bio)                 // pointer conversion
+	out.Email = conversion.Pointer(arg.Email)             // pointer conversion
+	return nil
+}
+
+func Convert_Profiles_ProfileMessages(args []*Profile) (outs []*ProfileMessage, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]ProfileMessage, len(args))
+	outs = make([]*ProfileMessage, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_Profile_ProfileMessage(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert github.com/olvrng/ggen-convert/tests.User --//
+
+func Apply_CreateUserArgs_User(arg *CreateUserArgs, out *User) (*User, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &User{}
+	}
+	if err := apply_CreateUserArgs_User(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func apply_CreateUserArgs_User(arg *CreateUserArgs, out *User) (err error) {
+	out.UserID = out.UserID // no change
+	out.FullName = arg.Name // simple assign
+	out.Email = arg.Email   // simple assign
+	return nil
+}
+
+func Apply_UpdateUserArgs_User(arg *UpdateUserArgs, out *User) (*User, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &User{}
+	}
+	if err := apply_UpdateUserArgs_User(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func apply_UpdateUserArgs_User(arg *UpdateUserArgs, out *User) (err error) {
+	out.UserID = out.UserID // identifier
+	out.FullName = arg.Name // simple assign
+	out.Email = out.Email   // no change
+	return nil
+}
+
+func Convert_UserInfo_User(arg *UserInfo, out *User) (*User, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &User{}
+	}
+	if err := convert_UserInfo_User(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_UserInfo_User(arg *UserInfo, out *User) (err error) {
+	out.UserID = arg.ID     // simple assign
+	out.FullName = arg.Name // simple assign
+	out.Email = arg.Email   // simple assign
+	return nil
+}
+
+func Convert_UserInfos_Users(args []*UserInfo) (outs []*User, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]User, len(args))
+	outs = make([]*User, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_UserInfo_User(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func Convert_User_UserInfo(arg *User, out *UserInfo) (*UserInfo, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if out == nil {
+		out = &UserInfo{}
+	}
+	if err := convert_User_UserInfo(arg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func convert_User_UserInfo(arg *User, out *UserInfo) (err error) {
+	out.ID = arg.UserID     // simple assign
+	out.Name = arg.FullName // simple assign
+	out.Email = arg.Email   // simple assign
+	return nil
+}
+
+func Convert_Users_UserInfos(args []*User) (outs []*UserInfo, err error) {
+	if args == nil {
+		return nil, nil
+	}
+	tmps := make([]UserInfo, len(args))
+	outs = make([]*UserInfo, len(args))
+	for i := range tmps {
+		if outs[i], err = Convert_User_UserInfo(args[i], &tmps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+//-- convert enums --//
+
+func convert_StatusCode_Status(arg StatusCode) (Status, error) {
+	switch arg {
+	// StatusCodeUnknown: no counterpart in Status
+	case StatusCodeActive:
+		return StatusActive, nil
+	case StatusCodeInactive:
+		return StatusInactive, nil
+	default:
+		return "", fmt.Errorf("can not convert StatusCode(%v) to Status", arg)
+	}
+}
+
+func convert_Status_StatusCode(arg Status) (StatusCode, error) {
+	switch arg {
+	case StatusActive:
+		return StatusCodeActive, nil
+	case StatusInactive:
+		return StatusCodeInactive, nil
+	// StatusArchived: no counterpart in StatusCode
+	default:
+		return StatusCodeUnknown, nil
 	}
-	return outs
 }